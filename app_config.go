@@ -11,6 +11,8 @@ var (
 	IDX_COMMENT_COUNT = 0
 	// 应用名称
 	APP_NAME = "GenDict"
+	// 应用 ID（与打包时的 --app-id 一致，Preferences 依赖此 ID 持久化）
+	APP_ID = "cn.iamcc.gendict"
 
 	// 开启调试模式
 	DEBUG = os.Getenv("DEBUG") == "true"
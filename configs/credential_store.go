@@ -0,0 +1,327 @@
+package configs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"goDict/utils"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// credentialStoreVersion 凭据库文件版本
+const credentialStoreVersion = 1
+
+// credentialStoreCheckText 用于校验主密码的明文
+const credentialStoreCheckText = "GenDict"
+
+// 凭据库文件中 Argon2id 参数的允许范围，防止篡改后降低强度或耗尽内存、CPU
+const (
+	credentialStoreMinTime    uint32 = 1
+	credentialStoreMaxTime    uint32 = 16
+	credentialStoreMinMemory  uint32 = 19 * 1024
+	credentialStoreMaxMemory  uint32 = 1024 * 1024
+	credentialStoreMinThreads uint8  = 1
+	credentialStoreMaxThreads uint8  = 16
+)
+
+var (
+	// ErrCredentialStoreLocked 凭据库未解锁
+	ErrCredentialStoreLocked = errors.New("凭据库未解锁")
+	// ErrCredentialStoreWrongSecret 主密码或密钥文件错误
+	ErrCredentialStoreWrongSecret = errors.New("主密码或密钥文件错误")
+	// ErrCredentialNotFound 凭据不存在
+	ErrCredentialNotFound = errors.New("凭据不存在")
+)
+
+// credentialStoreFile 凭据库文件结构，密钥由主密码经 Argon2id 派生，每项凭据单独使用 AES-GCM 加密
+type credentialStoreFile struct {
+	Version int    `json:"version"`
+	Kdf     string `json:"kdf"`
+	Salt    []byte `json:"salt"`
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+	// 校验主密码的密文
+	Check []byte `json:"check"`
+	// 凭据名 => 密文
+	Secrets map[string][]byte `json:"secrets"`
+}
+
+// CredentialStore 加密凭据库
+type CredentialStore struct {
+	path  string
+	data  *credentialStoreFile
+	key   []byte
+	mutex sync.RWMutex
+}
+
+var (
+	defaultCredentialStore     *CredentialStore
+	defaultCredentialStoreOnce sync.Once
+)
+
+// GetCredentialStore 获取默认凭据库（用户配置目录下的 GenDict/credentials.json）
+func GetCredentialStore() *CredentialStore {
+	defaultCredentialStoreOnce.Do(func() {
+		configDir, err := os.UserConfigDir()
+		if nil != err {
+			configDir = "."
+		}
+		defaultCredentialStore = NewCredentialStore(filepath.Join(configDir, "GenDict", "credentials.json"))
+	})
+	return defaultCredentialStore
+}
+
+// NewCredentialStore 创建凭据库，文件在首次解锁时创建
+func NewCredentialStore(path string) *CredentialStore {
+	return &CredentialStore{path: path}
+}
+
+// CredentialKey 生成数据库配置对应的凭据名，只由连接地址、用户及库名决定；
+// 不含类型，连接时 MySQL 会按版本识别为 MariaDB、TiDB，含类型时保存与读取的凭据名不一致
+func CredentialKey(config *DatabaseConfig) string {
+	database := config.Database
+	if "Oracle" == config.Type {
+		database = config.Service
+	}
+	return fmt.Sprintf("%s@%s:%d/%s", config.Username, config.Host, config.Port, database)
+}
+
+// SSHCredentialKey 生成 SSH 跳板机对应的凭据名
+func SSHCredentialKey(config *DatabaseConfig) string {
+	port := config.SSHPort
+	if 0 == port {
+		port = sshDefaultPort
+	}
+	return fmt.Sprintf("ssh://%s@%s:%d", config.SSHUser, config.SSHHost, port)
+}
+
+// Path 凭据库文件路径
+func (this *CredentialStore) Path() string {
+	return this.path
+}
+
+// Exists 凭据库文件是否存在
+func (this *CredentialStore) Exists() bool {
+	return utils.FileExists(this.path)
+}
+
+// IsUnlocked 是否已解锁
+func (this *CredentialStore) IsUnlocked() bool {
+	this.mutex.RLock()
+	defer this.mutex.RUnlock()
+	return nil != this.key
+}
+
+// Unlock 使用主密码解锁，凭据库不存在时以该密码创建
+func (this *CredentialStore) Unlock(secret []byte) error {
+	if 0 == len(secret) {
+		return ErrCredentialStoreWrongSecret
+	}
+
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	// 首次使用，创建凭据库
+	if !this.Exists() {
+		salt, err := utils.RandomBytes(16)
+		if nil != err {
+			return err
+		}
+		data := &credentialStoreFile{
+			Version: credentialStoreVersion,
+			Kdf:     "argon2id",
+			Salt:    salt,
+			Time:    utils.Argon2Time,
+			Memory:  utils.Argon2Memory,
+			Threads: utils.Argon2Threads,
+			Secrets: map[string][]byte{},
+		}
+		key := utils.DeriveKey(secret, data.Salt, data.Time, data.Memory, data.Threads)
+		if data.Check, err = utils.EncryptAESGCM(key, []byte(credentialStoreCheckText), nil); nil != err {
+			return err
+		}
+
+		this.data, this.key = data, key
+		return this.save()
+	}
+
+	data, err := this.load()
+	if nil != err {
+		return err
+	}
+	key := utils.DeriveKey(secret, data.Salt, data.Time, data.Memory, data.Threads)
+	if check, err := utils.DecryptAESGCM(key, data.Check, nil); nil != err || credentialStoreCheckText != string(check) {
+		return ErrCredentialStoreWrongSecret
+	}
+
+	this.data, this.key = data, key
+	return nil
+}
+
+// UnlockWithKeyFile 使用密钥文件的内容作为主密码解锁
+func (this *CredentialStore) UnlockWithKeyFile(keyFilePath string) error {
+	secret, err := os.ReadFile(keyFilePath)
+	if nil != err {
+		return fmt.Errorf("读取密钥文件失败: %w", err)
+	}
+	return this.Unlock(secret)
+}
+
+// Lock 锁定凭据库，清除内存中的密钥
+func (this *CredentialStore) Lock() {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	for i := range this.key {
+		this.key[i] = 0
+	}
+	this.key, this.data = nil, nil
+}
+
+// Get 读取凭据
+func (this *CredentialStore) Get(name string) (string, error) {
+	this.mutex.RLock()
+	defer this.mutex.RUnlock()
+
+	if nil == this.key {
+		return "", ErrCredentialStoreLocked
+	}
+	ciphertext, ok := this.data.Secrets[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrCredentialNotFound, name)
+	}
+
+	// 以凭据名作为附加数据，防止密文被挪用到其他凭据
+	plaintext, err := utils.DecryptAESGCM(this.key, ciphertext, []byte(name))
+	if nil != err {
+		return "", fmt.Errorf("凭据解密失败: %s", name)
+	}
+	return string(plaintext), nil
+}
+
+// Has 凭据是否存在
+func (this *CredentialStore) Has(name string) bool {
+	this.mutex.RLock()
+	defer this.mutex.RUnlock()
+
+	if nil == this.data {
+		return false
+	}
+	_, ok := this.data.Secrets[name]
+	return ok
+}
+
+// Set 写入凭据并保存
+func (this *CredentialStore) Set(name string, secret string) error {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	if nil == this.key {
+		return ErrCredentialStoreLocked
+	}
+	ciphertext, err := utils.EncryptAESGCM(this.key, []byte(secret), []byte(name))
+	if nil != err {
+		return err
+	}
+	this.data.Secrets[name] = ciphertext
+	return this.save()
+}
+
+// Delete 删除凭据并保存
+func (this *CredentialStore) Delete(name string) error {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	if nil == this.key {
+		return ErrCredentialStoreLocked
+	}
+	delete(this.data.Secrets, name)
+	return this.save()
+}
+
+// NameList 凭据名列表
+func (this *CredentialStore) NameList() []string {
+	this.mutex.RLock()
+	defer this.mutex.RUnlock()
+
+	if nil == this.data {
+		return nil
+	}
+	nameList := make([]string, 0, len(this.data.Secrets))
+	for name := range this.data.Secrets {
+		nameList = append(nameList, name)
+	}
+	sort.Strings(nameList)
+	return nameList
+}
+
+// load 读取凭据库文件
+func (this *CredentialStore) load() (*credentialStoreFile, error) {
+	bytes, err := os.ReadFile(this.path)
+	if nil != err {
+		return nil, fmt.Errorf("读取凭据库失败: %w", err)
+	}
+
+	data := &credentialStoreFile{}
+	if err = json.Unmarshal(bytes, data); nil != err {
+		return nil, fmt.Errorf("凭据库格式错误: %w", err)
+	}
+	if credentialStoreVersion != data.Version || "argon2id" != data.Kdf {
+		return nil, fmt.Errorf("不支持的凭据库版本: %d/%s", data.Version, data.Kdf)
+	}
+	if data.Time < credentialStoreMinTime || data.Time > credentialStoreMaxTime ||
+		data.Memory < credentialStoreMinMemory || data.Memory > credentialStoreMaxMemory ||
+		data.Threads < credentialStoreMinThreads || data.Threads > credentialStoreMaxThreads {
+		return nil, fmt.Errorf("凭据库 Argon2id 参数超出范围: time=%d, memory=%d, threads=%d", data.Time, data.Memory, data.Threads)
+	}
+	if nil == data.Secrets {
+		data.Secrets = map[string][]byte{}
+	}
+	return data, nil
+}
+
+// save 保存凭据库文件，先写临时文件再替换，避免写入中断损坏原文件
+func (this *CredentialStore) save() error {
+	bytes, err := json.MarshalIndent(this.data, "", "  ")
+	if nil != err {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(this.path), 0700); nil != err {
+		return err
+	}
+	tmpPath := this.path + ".tmp"
+	if err = os.WriteFile(tmpPath, bytes, 0600); nil != err {
+		return err
+	}
+	return os.Rename(tmpPath, this.path)
+}
+
+// resolveCredentials 从凭据库解析密码，返回解析后的配置副本（不修改原配置，避免明文被回写保存）
+func resolveCredentials(config *DatabaseConfig) (*DatabaseConfig, error) {
+	if "" == config.PasswordRef && "" == config.SSHPasswordRef {
+		return config, nil
+	}
+
+	store := GetCredentialStore()
+	resolvedConfig := *config
+	if "" != config.PasswordRef {
+		password, err := store.Get(config.PasswordRef)
+		if nil != err {
+			return nil, err
+		}
+		resolvedConfig.Password = password
+	}
+	if "" != config.SSHPasswordRef {
+		password, err := store.Get(config.SSHPasswordRef)
+		if nil != err {
+			return nil, err
+		}
+		resolvedConfig.SSHPassword = password
+	}
+	return &resolvedConfig, nil
+}
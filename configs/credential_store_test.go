@@ -0,0 +1,82 @@
+package configs
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCredentialKey(t *testing.T) {
+	// 连接时识别到的类型不影响凭据名
+	config := &DatabaseConfig{Type: "MySQL", Host: "db.local", Port: 3306, Username: "root", Database: "shop"}
+	key := CredentialKey(config)
+	if "root@db.local:3306/shop" != key {
+		t.Errorf("key = %s, want root@db.local:3306/shop", key)
+	}
+	for _, detectedType := range []string{"MariaDB", "TiDB"} {
+		detectedConfig := *config
+		detectedConfig.Type = detectedType
+		if detectedKey := CredentialKey(&detectedConfig); key != detectedKey {
+			t.Errorf("%s key = %s, want %s", detectedType, detectedKey, key)
+		}
+	}
+
+	// Oracle 使用服务名
+	oracleConfig := &DatabaseConfig{Type: "Oracle", Host: "ora.local", Port: 1521, Username: "scott", Service: "ORCLPDB1"}
+	if key = CredentialKey(oracleConfig); "scott@ora.local:1521/ORCLPDB1" != key {
+		t.Errorf("oracle key = %s, want scott@ora.local:1521/ORCLPDB1", key)
+	}
+}
+
+func TestCredentialStoreDetectedType(t *testing.T) {
+	store := NewCredentialStore(filepath.Join(t.TempDir(), "credentials.json"))
+	if err := store.Unlock([]byte("master")); nil != err {
+		t.Fatal(err)
+	}
+
+	// 按界面选择的类型保存，连接后类型被识别为 MariaDB，仍能读取
+	config := &DatabaseConfig{Type: "MySQL", Host: "db.local", Port: 3306, Username: "root", Database: "shop"}
+	if err := store.Set(CredentialKey(config), "secret"); nil != err {
+		t.Fatal(err)
+	}
+	config.Type = "MariaDB"
+	if password, err := store.Get(CredentialKey(config)); nil != err || "secret" != password {
+		t.Errorf("password = %q, err = %v, want secret", password, err)
+	}
+}
+
+func TestCredentialStoreArgon2Range(t *testing.T) {
+	storePath := filepath.Join(t.TempDir(), "credentials.json")
+	if err := NewCredentialStore(storePath).Unlock([]byte("master")); nil != err {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(storePath)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	// 文件中的参数超出范围时在派生密钥前拒绝
+	for name, modify := range map[string]func(data *credentialStoreFile){
+		"time":    func(data *credentialStoreFile) { data.Time = 0 },
+		"memory":  func(data *credentialStoreFile) { data.Memory = math.MaxUint32 },
+		"threads": func(data *credentialStoreFile) { data.Threads = 255 },
+	} {
+		data := &credentialStoreFile{}
+		if err = json.Unmarshal(content, data); nil != err {
+			t.Fatal(err)
+		}
+		modify(data)
+		modified, err := json.Marshal(data)
+		if nil != err {
+			t.Fatal(err)
+		}
+		if err = os.WriteFile(storePath, modified, 0600); nil != err {
+			t.Fatal(err)
+		}
+		if err = NewCredentialStore(storePath).Unlock([]byte("master")); nil == err {
+			t.Errorf("%s out of range accepted", name)
+		}
+	}
+}
@@ -5,15 +5,16 @@ import (
 	"fmt"
 	"github.com/seelly/gorm-oracle"
 	"goDict/utils"
-	"gorm.io/driver/mysql"
-	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
-	"gorm.io/driver/sqlserver"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"log/slog"
 	_ "log/slog"
 	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 )
 
 // DatabaseConfig 数据库配置结构
@@ -26,22 +27,111 @@ type DatabaseConfig struct {
 	Service  string `yaml:"service"`
 	Username string `yaml:"username"`
 	Password string `yaml:"password"`
-	Database string `yaml:"database"`
-	Charset  string `yaml:"charset"`
+	// 凭据库中的密码名，指定后连接时从凭据库读取密码
+	PasswordRef string `yaml:"password_ref"`
+	Database    string `yaml:"database"`
+	Charset     string `yaml:"charset"`
+	// 附加的驱动参数，会覆盖同名的默认参数
+	Params map[string]string `yaml:"params"`
+
+	// TLS/SSL 模式：disable、require、verify-ca、verify-full，为空时沿用驱动默认行为
+	SSLMode string `yaml:"ssl_mode"`
+	// CA 证书文件（Oracle 为 wallet 目录）
+	SSLRootCert string `yaml:"ssl_root_cert"`
+	// 客户端证书及私钥文件
+	SSLCert string `yaml:"ssl_cert"`
+	SSLKey  string `yaml:"ssl_key"`
+	// 校验证书时使用的服务器名，为空时使用 Host
+	SSLServerName string `yaml:"ssl_server_name"`
+
+	// SSH 隧道（跳板机），SSHHost 为空时直连
+	SSHHost     string `yaml:"ssh_host"`
+	SSHPort     int    `yaml:"ssh_port"`
+	SSHUser     string `yaml:"ssh_user"`
+	SSHPassword string `yaml:"ssh_password"`
+	// 凭据库中的 SSH 密码名
+	SSHPasswordRef string `yaml:"ssh_password_ref"`
+	// 私钥文件及其口令
+	SSHKeyFile       string `yaml:"ssh_key_file"`
+	SSHKeyPassphrase string `yaml:"ssh_key_passphrase"`
+	// known_hosts 文件，为空时不校验主机密钥
+	SSHKnownHosts string `yaml:"ssh_known_hosts"`
 }
 
 // DB 全局数据库实例
 var DB *gorm.DB
 
-// InitDatabase 初始化数据库连接
-func InitDatabase(config *DatabaseConfig) (*gorm.DB, error) {
-	var dialector gorm.Dialector
+// sshTunnelMap 数据库连接对应的 SSH 隧道，关闭连接时一并关闭
+var sshTunnelMap sync.Map
 
+// InitDatabase 初始化数据库连接，使用完毕后需调用 CloseDatabase 释放连接及隧道
+func InitDatabase(config *DatabaseConfig) (*gorm.DB, error) {
 	// 初始化数据库配置
 	if nil == config {
 		return nil, errors.New("数据库连接错误")
 	}
 
+	// 从凭据库读取密码
+	connConfig, err := resolveCredentials(config)
+	if nil != err {
+		slog.Error("读取凭据失败", "error", err)
+		return nil, err
+	}
+
+	// 通过 SSH 隧道连接时，改为连接隧道的本地端口
+	var tunnel *SSHTunnel
+	if config.IsSSHEnabled() {
+		if tunnel, err = OpenSSHTunnel(connConfig); nil != err {
+			slog.Error("SSH 隧道建立失败", "error", err)
+			return nil, err
+		}
+		connConfig = tunnel.localConfig(connConfig)
+	}
+
+	db, err := openDatabase(connConfig)
+	if nil != err {
+		if nil != tunnel {
+			_ = tunnel.Close()
+		}
+		return nil, err
+	}
+	// 连接时可能修正了数据库类型及库名
+	config.Type = connConfig.Type
+	config.Database = connConfig.Database
+	if nil != tunnel {
+		sshTunnelMap.Store(db, tunnel)
+	}
+
+	DB = db
+	return db, nil
+}
+
+// CloseDatabase 关闭数据库连接及其 SSH 隧道
+func CloseDatabase(db *gorm.DB) error {
+	if nil == db {
+		return nil
+	}
+
+	var errList []error
+	if sqlDB, err := db.DB(); nil != err {
+		errList = append(errList, err)
+	} else {
+		errList = append(errList, sqlDB.Close())
+	}
+	if value, ok := sshTunnelMap.LoadAndDelete(db); ok {
+		errList = append(errList, value.(*SSHTunnel).Close())
+	}
+
+	if DB == db {
+		DB = nil
+	}
+	return errors.Join(errList...)
+}
+
+// openDatabase 按数据库类型打开连接
+func openDatabase(config *DatabaseConfig) (*gorm.DB, error) {
+	var dialector gorm.Dialector
+
 	// 如果是 SQLite 类型，需要判断文件是否存在
 	if "sqlite" == config.Type {
 		if !utils.FileExists(config.Database) {
@@ -51,28 +141,84 @@ func InitDatabase(config *DatabaseConfig) (*gorm.DB, error) {
 		}
 	}
 
-	// 对密码进行编码，确保它能安全地放在DSN或URL中
-	encodedPassword := url.QueryEscape(config.Password)
+	// 如果是 DuckDB 类型，同样需要判断文件是否存在（不存在时驱动会创建空库）
+	if "DuckDB" == config.Type {
+		if !utils.FileExists(config.Host) {
+			err := errors.New("数据库文件不存在")
+			slog.Error("数据库文件不存在", "error", err)
+			return nil, err
+		}
+		// 库名（catalog）固定为不含扩展名的文件名
+		srcFileName := filepath.Base(config.Host)
+		config.Database = strings.TrimSuffix(srcFileName, filepath.Ext(srcFileName))
+	}
 
+	var err error
 	switch config.Type {
-	case "MySQL":
-		dsn := "%s:%s@tcp(%s:%d)/%s?charset=%s&parseTime=True&loc=Local"
-		dsn = fmt.Sprintf(dsn, config.Username, config.Password, config.Host, config.Port, config.Database, config.Charset)
-		dialector = mysql.Open(dsn)
+	case "MySQL", "MariaDB", "TiDB":
+		// MariaDB、TiDB 复用 MySQL 驱动，连接后根据版本自动识别
+		defaultParams := map[string]string{"charset": config.Charset, "parseTime": "True", "loc": "Local"}
+		if SSLModeDisable == config.SSLMode {
+			defaultParams["tls"] = "false"
+		} else if config.IsTLSEnabled() {
+			if defaultParams["tls"], err = registerMySQLTLSConfig(config); nil != err {
+				return nil, err
+			}
+		}
+		query := buildUrlQuery(defaultParams, config.Params)
+		dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?%s", config.Username, config.Password, config.Host, config.Port, config.Database, query)
+		dialector = OpenMySQLFlavor(dsn)
 	case "PostgresSQL":
-		dsn := "host=%s user=%s password=%s dbname=%s port=%d sslmode=disable TimeZone=Asia/Shanghai"
-		dsn = fmt.Sprintf(dsn, config.Host, config.Username, encodedPassword, config.Database, config.Port)
-		dialector = postgres.Open(dsn)
+		// 启用 TLS 时由 tls.Config 接管，sslmode 参数不再生效
+		dsn := buildKeyValueDSN(map[string]string{
+			"host":     config.Host,
+			"user":     config.Username,
+			"password": config.Password,
+			"dbname":   config.Database,
+			"port":     strconv.Itoa(config.Port),
+			"sslmode":  "disable",
+			"TimeZone": "Asia/Shanghai",
+		}, config.Params, " ", quotePostgresValue)
+		if dialector, err = openPostgres(dsn, config); nil != err {
+			return nil, err
+		}
 	case "SQLServer":
-		dsn := "server=%s;user id=%s;password=%s;port=%d;database=%s;TrustServerCertificate=true;encrypt=disable"
-		dsn = fmt.Sprintf(dsn, config.Host, config.Username, config.Password, config.Port, config.Database)
-		dialector = sqlserver.Open(dsn)
+		// 使用 URL 形式，避免密码等值中的分号破坏 ADO 格式
+		host, instance, _ := strings.Cut(config.Host, `\`)
+		u := url.URL{
+			Scheme:   "sqlserver",
+			User:     url.UserPassword(config.Username, config.Password),
+			Host:     fmt.Sprintf("%s:%d", host, config.Port),
+			Path:     instance,
+			RawQuery: buildUrlQuery(map[string]string{"database": config.Database, "TrustServerCertificate": "true", "encrypt": "disable"}, config.Params),
+		}
+		if dialector, err = openSqlServer(u.String(), config); nil != err {
+			return nil, err
+		}
 	case "Oracle":
-		dsn := oracle.BuildUrl(config.Host, config.Port, config.Service, config.Username, config.Password, nil)
+		dsn := oracle.BuildUrl(config.Host, config.Port, config.Service, config.Username, config.Password, buildOracleOptions(config))
 		dialector = oracle.Open(dsn)
 	case "SQLite":
 		dsn := config.Host
+		if 0 < len(config.Params) {
+			dsn += "?" + buildUrlQuery(nil, config.Params)
+		}
 		dialector = sqlite.Open(dsn)
+	case "ClickHouse":
+		u := url.URL{
+			Scheme:   "clickhouse",
+			User:     url.UserPassword(config.Username, config.Password),
+			Host:     fmt.Sprintf("%s:%d", config.Host, config.Port),
+			Path:     "/" + config.Database,
+			RawQuery: buildUrlQuery(map[string]string{"dial_timeout": "10s", "read_timeout": "60s"}, config.Params),
+		}
+		if dialector, err = openClickHouse(u.String(), config); nil != err {
+			return nil, err
+		}
+	case "DuckDB":
+		// 默认只读打开，避免锁定或修改源文件
+		dsn := config.Host + "?" + buildUrlQuery(map[string]string{"access_mode": "READ_ONLY"}, config.Params)
+		dialector = OpenDuckDB(dsn)
 	default:
 		return nil, fmt.Errorf("unsupported database type: %s", config.Type)
 	}
@@ -87,6 +233,13 @@ func InitDatabase(config *DatabaseConfig) (*gorm.DB, error) {
 		return nil, err
 	}
 
-	DB = db
+	// 以探测到的类型为准
+	if flavorDialector, ok := dialector.(*MySQLFlavorDialector); ok {
+		if detectedType := MySQLFlavorTypeMap[flavorDialector.Flavor]; detectedType != config.Type {
+			slog.Info("识别到数据库类型", "selected", config.Type, "detected", detectedType, "version", flavorDialector.ServerVersion)
+			config.Type = detectedType
+		}
+	}
+
 	return db, nil
 }
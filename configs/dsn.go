@@ -0,0 +1,379 @@
+package configs
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// dsnSchemeTypeMap URL 协议与数据库类型的对应关系
+var dsnSchemeTypeMap = map[string]string{
+	"mysql":      "MySQL",
+	"mariadb":    "MariaDB",
+	"tidb":       "TiDB",
+	"postgres":   "PostgresSQL",
+	"postgresql": "PostgresSQL",
+	"sqlserver":  "SQLServer",
+	"mssql":      "SQLServer",
+	"oracle":     "Oracle",
+	"clickhouse": "ClickHouse",
+	"sqlite":     "SQLite",
+	"sqlite3":    "SQLite",
+	"file":       "SQLite",
+	"duckdb":     "DuckDB",
+}
+
+// dsnOpaqueSchemeList 可省略 // 的协议（sqlite:test.db、file:test.db?mode=ro），其他协议须为 scheme:// 形式，
+// 否则用户名与协议同名的 MySQL 驱动格式（如 mysql:pass@tcp(host:3306)/db）会被当作 URL
+var dsnOpaqueSchemeList = []string{"sqlite", "sqlite3", "file"}
+
+// dsnDefaultPortMap 连接串未指定端口时使用的默认端口
+var dsnDefaultPortMap = map[string]int{
+	"MySQL":       3306,
+	"MariaDB":     3306,
+	"TiDB":        4000,
+	"PostgresSQL": 5432,
+	"SQLServer":   1433,
+	"Oracle":      1521,
+	"ClickHouse":  9000,
+}
+
+// postgresKeyValueRegexp 匹配 PostgreSQL 的 key=value 形式（值可用单引号包裹）
+var postgresKeyValueRegexp = regexp.MustCompile(`([\w.]+)\s*=\s*('(?:[^'\\]|\\.)*'|\S*)`)
+
+// ParseDSN 将完整的连接串（驱动 DSN 或 URL）解析为数据库配置，未识别的参数保留在 Params 中
+//
+// 支持的格式：
+//   - URL：mysql://、mariadb://、tidb://、postgres://、sqlserver://、oracle://、clickhouse://、sqlite://、duckdb://，
+//     SQLite 也可为 sqlite:test.db、file:test.db?mode=ro
+//   - MySQL 驱动格式：user:pass@tcp(host:port)/db?charset=utf8mb4
+//   - SQL Server ADO 格式：server=host;user id=sa;password=...;database=db
+//   - PostgreSQL key=value 格式：host=localhost user=postgres dbname=db sslmode=require
+//   - 文件路径：*.duckdb 识别为 DuckDB，其余识别为 SQLite
+func ParseDSN(dsn string) (*DatabaseConfig, error) {
+	dsn = strings.TrimSpace(dsn)
+	if "" == dsn {
+		return nil, errors.New("连接串不能为空")
+	}
+
+	config, err := parseDSN(dsn)
+	if nil != err {
+		return nil, err
+	}
+
+	// MySQL 系列未指定字符集时使用默认值
+	if _, ok := MySQLFlavorTypeMap[strings.ToLower(config.Type)]; ok && "" == config.Charset {
+		config.Charset = "utf8mb4"
+	}
+	return config, nil
+}
+
+// parseDSN 按连接串的形式选择解析方式
+func parseDSN(dsn string) (*DatabaseConfig, error) {
+	if scheme, rest, found := strings.Cut(dsn, ":"); found {
+		scheme = strings.ToLower(scheme)
+		if _, ok := dsnSchemeTypeMap[scheme]; ok && (strings.HasPrefix(rest, "//") || slices.Contains(dsnOpaqueSchemeList, scheme)) {
+			return parseUrlDSN(dsn)
+		}
+	}
+
+	switch {
+	case strings.Contains(dsn, "@") && strings.Contains(dsn, "/") && !strings.Contains(dsn, ";"):
+		return parseMySQLDSN(dsn)
+	case strings.Contains(dsn, ";"):
+		return parseSqlServerDSN(dsn)
+	case strings.Contains(dsn, "="):
+		return parsePostgresDSN(dsn)
+	default:
+		return parseFileDSN(dsn, ""), nil
+	}
+}
+
+// parseUrlDSN 解析 URL 形式的连接串
+func parseUrlDSN(dsn string) (*DatabaseConfig, error) {
+	u, err := url.Parse(dsn)
+	if nil != err {
+		return nil, fmt.Errorf("连接串格式错误: %w", err)
+	}
+
+	dbType := dsnSchemeTypeMap[strings.ToLower(u.Scheme)]
+
+	// 文件型数据库，路径可能在 Opaque（sqlite:test.db）或 Host+Path（sqlite://./test.db）中
+	if "SQLite" == dbType || "DuckDB" == dbType {
+		path := u.Opaque
+		if "" == path {
+			path = u.Host + u.Path
+		}
+		config := parseFileDSN(path, dbType)
+		putQueryParams(config, u.Query())
+		return config, nil
+	}
+
+	config := &DatabaseConfig{
+		Type: dbType,
+		Host: u.Hostname(),
+		Port: dsnDefaultPortMap[dbType],
+	}
+	if "" != u.Port() {
+		if config.Port, err = strconv.Atoi(u.Port()); nil != err {
+			return nil, fmt.Errorf("端口格式错误: %s", u.Port())
+		}
+	}
+	if nil != u.User {
+		config.Username = u.User.Username()
+		config.Password, _ = u.User.Password()
+	}
+
+	query := u.Query()
+	path := strings.Trim(u.Path, "/")
+	switch dbType {
+	case "Oracle":
+		// 路径为服务名，Oracle 的库（schema）即为用户名
+		config.Service = path
+		config.Database = config.Username
+	case "SQLServer":
+		// 路径为实例名，库名由 database 参数指定
+		if "" != path {
+			config.Host += `\` + path
+		}
+		config.Database = query.Get("database")
+		query.Del("database")
+	default:
+		config.Database = path
+	}
+
+	putQueryParams(config, query)
+	return config, nil
+}
+
+// parseMySQLDSN 解析 go-sql-driver 格式的连接串：[user[:password]@][net[(addr)]]/dbname[?param1=value1]
+func parseMySQLDSN(dsn string) (*DatabaseConfig, error) {
+	config := &DatabaseConfig{
+		Type: "MySQL",
+		Port: dsnDefaultPortMap["MySQL"],
+	}
+
+	slashIndex := strings.LastIndex(dsn, "/")
+	prefix, suffix := dsn[:slashIndex], dsn[slashIndex+1:]
+
+	// 密码中可能含有 @，以最后一个为准
+	if atIndex := strings.LastIndex(prefix, "@"); atIndex >= 0 {
+		config.Username, config.Password, _ = strings.Cut(prefix[:atIndex], ":")
+		prefix = prefix[atIndex+1:]
+	}
+
+	// tcp(host:port)
+	if openIndex := strings.Index(prefix, "("); openIndex >= 0 {
+		if !strings.HasSuffix(prefix, ")") {
+			return nil, errors.New("连接串格式错误: 地址缺少右括号")
+		}
+		addr := prefix[openIndex+1 : len(prefix)-1]
+		if host, port, found := strings.Cut(addr, ":"); found {
+			config.Host = host
+			if p, err := strconv.Atoi(port); nil == err {
+				config.Port = p
+			} else {
+				return nil, fmt.Errorf("端口格式错误: %s", port)
+			}
+		} else {
+			config.Host = addr
+		}
+	}
+	if "" == config.Host {
+		config.Host = "127.0.0.1"
+	}
+
+	database, rawQuery, _ := strings.Cut(suffix, "?")
+	config.Database = database
+
+	query, err := url.ParseQuery(rawQuery)
+	if nil != err {
+		return nil, fmt.Errorf("连接串参数格式错误: %w", err)
+	}
+	putQueryParams(config, query)
+	return config, nil
+}
+
+// parseSqlServerDSN 解析 SQL Server ADO 格式的连接串：server=host,port;user id=sa;password=...;database=db
+func parseSqlServerDSN(dsn string) (*DatabaseConfig, error) {
+	config := &DatabaseConfig{
+		Type: "SQLServer",
+		Port: dsnDefaultPortMap["SQLServer"],
+	}
+
+	for _, part := range strings.Split(dsn, ";") {
+		key, value, found := strings.Cut(part, "=")
+		if !found {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		switch strings.ToLower(key) {
+		case "server", "data source", "address", "addr", "network address":
+			// 可能为 tcp:host,port 或 host\instance
+			value = strings.TrimPrefix(value, "tcp:")
+			if host, port, found := strings.Cut(value, ","); found {
+				value = host
+				if p, err := strconv.Atoi(strings.TrimSpace(port)); nil == err {
+					config.Port = p
+				}
+			}
+			config.Host = value
+		case "port":
+			p, err := strconv.Atoi(value)
+			if nil != err {
+				return nil, fmt.Errorf("端口格式错误: %s", value)
+			}
+			config.Port = p
+		case "user id", "uid", "user":
+			config.Username = value
+		case "password", "pwd":
+			config.Password = value
+		case "database", "initial catalog":
+			config.Database = value
+		default:
+			putParam(config, key, value)
+		}
+	}
+
+	if "" == config.Host {
+		return nil, errors.New("连接串格式错误: 缺少 server")
+	}
+	return config, nil
+}
+
+// parsePostgresDSN 解析 PostgreSQL key=value 格式的连接串
+func parsePostgresDSN(dsn string) (*DatabaseConfig, error) {
+	config := &DatabaseConfig{
+		Type: "PostgresSQL",
+		Port: dsnDefaultPortMap["PostgresSQL"],
+	}
+
+	matchList := postgresKeyValueRegexp.FindAllStringSubmatch(dsn, -1)
+	if 0 == len(matchList) {
+		return nil, errors.New("连接串格式错误")
+	}
+
+	for _, match := range matchList {
+		key, value := match[1], match[2]
+		if strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") && len(value) >= 2 {
+			value = strings.NewReplacer(`\'`, `'`, `\\`, `\`).Replace(value[1 : len(value)-1])
+		}
+
+		switch key {
+		case "host":
+			config.Host = value
+		case "port":
+			p, err := strconv.Atoi(value)
+			if nil != err {
+				return nil, fmt.Errorf("端口格式错误: %s", value)
+			}
+			config.Port = p
+		case "user":
+			config.Username = value
+		case "password":
+			config.Password = value
+		case "dbname":
+			config.Database = value
+		default:
+			putParam(config, key, value)
+		}
+	}
+	return config, nil
+}
+
+// parseFileDSN 解析文件路径，未指定类型时按扩展名判断 DuckDB 或 SQLite
+func parseFileDSN(path string, dbType string) *DatabaseConfig {
+	// 去掉路径上附带的参数，参数由调用方处理
+	path, _, _ = strings.Cut(path, "?")
+
+	if "" == dbType {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".duckdb", ".ddb":
+			dbType = "DuckDB"
+		default:
+			dbType = "SQLite"
+		}
+	}
+
+	return &DatabaseConfig{
+		Type: dbType,
+		Host: path,
+	}
+}
+
+// putQueryParams 将 URL 参数写入配置，charset 单独处理
+func putQueryParams(config *DatabaseConfig, query url.Values) {
+	for key := range query {
+		putParam(config, key, query.Get(key))
+	}
+}
+
+// putParam 写入单个参数
+func putParam(config *DatabaseConfig, key string, value string) {
+	if "charset" == strings.ToLower(key) && "" == config.Charset {
+		config.Charset = value
+		return
+	}
+	if nil == config.Params {
+		config.Params = make(map[string]string)
+	}
+	config.Params[key] = value
+}
+
+// mergeParams 合并默认参数与自定义参数，自定义参数优先（键名不区分大小写），返回按键名排序的键列表
+func mergeParams(defaultParams map[string]string, params map[string]string) ([]string, map[string]string) {
+	result := make(map[string]string, len(defaultParams)+len(params))
+	for key, value := range defaultParams {
+		result[key] = value
+	}
+	for key, value := range params {
+		for defaultKey := range defaultParams {
+			if strings.EqualFold(defaultKey, key) {
+				delete(result, defaultKey)
+			}
+		}
+		result[key] = value
+	}
+
+	keyList := make([]string, 0, len(result))
+	for key := range result {
+		keyList = append(keyList, key)
+	}
+	sort.Strings(keyList)
+	return keyList, result
+}
+
+// buildUrlQuery 生成 URL 参数：k1=v1&k2=v2
+func buildUrlQuery(defaultParams map[string]string, params map[string]string) string {
+	keyList, merged := mergeParams(defaultParams, params)
+	partList := make([]string, 0, len(keyList))
+	for _, key := range keyList {
+		partList = append(partList, url.QueryEscape(key)+"="+url.QueryEscape(merged[key]))
+	}
+	return strings.Join(partList, "&")
+}
+
+// buildKeyValueDSN 生成 key=value 形式的参数，sep 为分隔符，quote 为值的转义方式
+func buildKeyValueDSN(defaultParams map[string]string, params map[string]string, sep string, quote func(string) string) string {
+	keyList, merged := mergeParams(defaultParams, params)
+	partList := make([]string, 0, len(keyList))
+	for _, key := range keyList {
+		partList = append(partList, key+"="+quote(merged[key]))
+	}
+	return strings.Join(partList, sep)
+}
+
+// quotePostgresValue 按 libpq 规则转义：含空格、单引号、反斜杠或为空时用单引号包裹
+func quotePostgresValue(value string) string {
+	if "" != value && !strings.ContainsAny(value, ` '\`) {
+		return value
+	}
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
@@ -0,0 +1,30 @@
+package configs
+
+import (
+	_ "github.com/marcboeker/go-duckdb"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// DuckDBDialector DuckDB 方言
+// DuckDB 语法与 PostgresSQL 基本兼容，复用 PostgresSQL 方言，仅替换驱动及名称
+type DuckDBDialector struct {
+	postgres.Dialector
+}
+
+// OpenDuckDB 打开 DuckDB 数据库文件
+func OpenDuckDB(dsn string) gorm.Dialector {
+	return &DuckDBDialector{
+		Dialector: postgres.Dialector{
+			Config: &postgres.Config{
+				DriverName: "duckdb",
+				DSN:        dsn,
+			},
+		},
+	}
+}
+
+// Name 方言名称
+func (this DuckDBDialector) Name() string {
+	return "duckdb"
+}
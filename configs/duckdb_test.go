@@ -0,0 +1,64 @@
+package configs
+
+import (
+	"database/sql"
+	"goDict/utils"
+	"path/filepath"
+	"testing"
+)
+
+// createDuckDBFile 在临时目录中创建 DuckDB 数据库文件并执行建表语句
+func createDuckDBFile(t *testing.T, name string, statementList ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	db, err := sql.Open("duckdb", path)
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	for _, statement := range statementList {
+		if _, err = db.Exec(statement); nil != err {
+			t.Fatalf("%s: %v", statement, err)
+		}
+	}
+	return path
+}
+
+func TestOpenDuckDB(t *testing.T) {
+	path := createDuckDBFile(t, "shop.duckdb", "CREATE TABLE product (id INTEGER PRIMARY KEY, name VARCHAR)")
+
+	config := &DatabaseConfig{Type: "DuckDB", Host: path}
+	db, err := openDatabase(config)
+	if nil != err {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	if "duckdb" != db.Dialector.Name() {
+		t.Errorf("dialector = %s, want duckdb", db.Dialector.Name())
+	}
+	// 库名为不含扩展名的文件名
+	if "shop" != config.Database {
+		t.Errorf("database = %s, want shop", config.Database)
+	}
+	var catalog string
+	if err = db.Raw("SELECT current_database()").Scan(&catalog).Error; nil != err || "shop" != catalog {
+		t.Errorf("current_database() = %s, err = %v, want shop", catalog, err)
+	}
+	// 只读打开
+	if err = db.Exec("INSERT INTO product VALUES (1, 'a')").Error; nil == err {
+		t.Error("insert succeeded on a read-only connection")
+	}
+}
+
+func TestOpenDuckDBFileNotExist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.duckdb")
+	if _, err := openDatabase(&DatabaseConfig{Type: "DuckDB", Host: path}); nil == err {
+		t.Fatal("openDatabase succeeded on a missing file")
+	}
+	// 不应创建空库
+	if utils.FileExists(path) {
+		t.Error("openDatabase created the missing file")
+	}
+}
@@ -0,0 +1,66 @@
+package configs
+
+import (
+	"context"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"strings"
+)
+
+// MySQL 兼容数据库的方言名称及显示名称
+var MySQLFlavorTypeMap = map[string]string{
+	"mysql":   "MySQL",
+	"mariadb": "MariaDB",
+	"tidb":    "TiDB",
+}
+
+// MySQLFlavorDialector MySQL 兼容方言（MySQL、MariaDB、TiDB）
+// 复用 MySQL 驱动，连接后根据服务器版本识别实际类型
+type MySQLFlavorDialector struct {
+	*mysql.Dialector
+	// mysql / mariadb / tidb
+	Flavor string
+	// 服务器版本
+	ServerVersion string
+}
+
+// OpenMySQLFlavor 打开 MySQL 兼容数据库
+func OpenMySQLFlavor(dsn string) *MySQLFlavorDialector {
+	return &MySQLFlavorDialector{
+		Dialector: mysql.Open(dsn).(*mysql.Dialector),
+		Flavor:    "mysql",
+	}
+}
+
+// Initialize 初始化并探测服务器版本
+func (this *MySQLFlavorDialector) Initialize(db *gorm.DB) error {
+	if err := this.Dialector.Initialize(db); nil != err {
+		return err
+	}
+
+	// MySQL 驱动初始化时读取的版本号不会回写，这里重新查询
+	err := db.ConnPool.QueryRowContext(context.Background(), "SELECT VERSION()").Scan(&this.ServerVersion)
+	if nil != err {
+		return err
+	}
+	this.Flavor = ProbeMySQLFlavor(this.ServerVersion)
+
+	return nil
+}
+
+// Name 方言名称
+func (this *MySQLFlavorDialector) Name() string {
+	return this.Flavor
+}
+
+// ProbeMySQLFlavor 根据服务器版本识别 MySQL 兼容数据库
+// 如 10.11.6-MariaDB、8.0.11-TiDB-v7.5.0
+func ProbeMySQLFlavor(serverVersion string) string {
+	if strings.Contains(serverVersion, "MariaDB") {
+		return "mariadb"
+	}
+	if strings.Contains(serverVersion, "TiDB") {
+		return "tidb"
+	}
+	return "mysql"
+}
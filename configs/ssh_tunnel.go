@@ -0,0 +1,200 @@
+package configs
+
+import (
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// sshDefaultPort SSH 默认端口
+const sshDefaultPort = 22
+
+// sshDialTimeout 连接跳板机的超时时间
+const sshDialTimeout = 10 * time.Second
+
+// SSHTunnel SSH 本地端口转发，将本地随机端口转发到跳板机后的数据库地址
+type SSHTunnel struct {
+	client     *ssh.Client
+	listener   net.Listener
+	remoteAddr string
+	waitGroup  sync.WaitGroup
+}
+
+// IsSSHEnabled 是否通过 SSH 隧道连接（文件型数据库不支持）
+func (this *DatabaseConfig) IsSSHEnabled() bool {
+	return "" != this.SSHHost && "SQLite" != this.Type && "DuckDB" != this.Type
+}
+
+// OpenSSHTunnel 连接跳板机并开始监听本地端口，转发目标为配置中的 Host:Port
+func OpenSSHTunnel(config *DatabaseConfig) (*SSHTunnel, error) {
+	clientConfig, err := buildSSHClientConfig(config)
+	if nil != err {
+		return nil, err
+	}
+
+	port := config.SSHPort
+	if 0 == port {
+		port = sshDefaultPort
+	}
+	client, err := ssh.Dial("tcp", net.JoinHostPort(config.SSHHost, strconv.Itoa(port)), clientConfig)
+	if nil != err {
+		return nil, fmt.Errorf("连接跳板机失败: %w", err)
+	}
+
+	// 只监听回环地址，避免隧道暴露给其他主机
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if nil != err {
+		_ = client.Close()
+		return nil, fmt.Errorf("监听本地端口失败: %w", err)
+	}
+
+	// SQL Server 的实例名不属于网络地址
+	host, _, _ := strings.Cut(config.Host, `\`)
+	tunnel := &SSHTunnel{
+		client:     client,
+		listener:   listener,
+		remoteAddr: net.JoinHostPort(host, strconv.Itoa(config.Port)),
+	}
+	// 接受连接的协程也计入等待，避免 Close 返回后才开始新的转发
+	tunnel.waitGroup.Add(1)
+	go tunnel.accept()
+
+	slog.Info("SSH 隧道已建立", "ssh", config.SSHHost, "local", listener.Addr().String(), "remote", tunnel.remoteAddr)
+	return tunnel, nil
+}
+
+// LocalPort 本地转发端口
+func (this *SSHTunnel) LocalPort() int {
+	return this.listener.Addr().(*net.TCPAddr).Port
+}
+
+// localConfig 复制数据库配置，地址改为隧道的本地端口
+func (this *SSHTunnel) localConfig(config *DatabaseConfig) *DatabaseConfig {
+	localConfig := *config
+	localConfig.Host = "127.0.0.1"
+	localConfig.Port = this.LocalPort()
+	// 证书仍需按原地址校验
+	if "" == localConfig.SSLServerName {
+		localConfig.SSLServerName, _, _ = strings.Cut(config.Host, `\`)
+	}
+	return &localConfig
+}
+
+// Close 关闭隧道，等待接受连接及所有转发的协程结束
+func (this *SSHTunnel) Close() error {
+	listenerErr := this.listener.Close()
+	clientErr := this.client.Close()
+	this.waitGroup.Wait()
+
+	slog.Info("SSH 隧道已关闭", "remote", this.remoteAddr)
+	if nil != clientErr && !errors.Is(clientErr, net.ErrClosed) {
+		return clientErr
+	}
+	if nil != listenerErr && !errors.Is(listenerErr, net.ErrClosed) {
+		return listenerErr
+	}
+	return nil
+}
+
+// accept 接受本地连接，每个连接单独转发
+func (this *SSHTunnel) accept() {
+	defer this.waitGroup.Done()
+
+	for {
+		localConn, err := this.listener.Accept()
+		if nil != err {
+			// 监听关闭后退出
+			return
+		}
+
+		this.waitGroup.Add(1)
+		go this.forward(localConn)
+	}
+}
+
+// forward 通过跳板机转发单个连接，任一方向结束即关闭两端
+func (this *SSHTunnel) forward(localConn net.Conn) {
+	defer this.waitGroup.Done()
+
+	remoteConn, err := this.client.Dial("tcp", this.remoteAddr)
+	if nil != err {
+		slog.Error("SSH 隧道转发失败", "remote", this.remoteAddr, "error", err)
+		_ = localConn.Close()
+		return
+	}
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(remoteConn, localConn)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(localConn, remoteConn)
+		done <- struct{}{}
+	}()
+	<-done
+
+	_ = localConn.Close()
+	_ = remoteConn.Close()
+	<-done
+}
+
+// buildSSHClientConfig 创建 SSH 客户端配置，支持私钥与密码认证
+func buildSSHClientConfig(config *DatabaseConfig) (*ssh.ClientConfig, error) {
+	if "" == config.SSHUser {
+		return nil, errors.New("SSH 用户名不能为空")
+	}
+
+	var authList []ssh.AuthMethod
+	if "" != config.SSHKeyFile {
+		pem, err := os.ReadFile(config.SSHKeyFile)
+		if nil != err {
+			return nil, fmt.Errorf("读取 SSH 私钥失败: %w", err)
+		}
+
+		var signer ssh.Signer
+		if "" != config.SSHKeyPassphrase {
+			signer, err = ssh.ParsePrivateKeyWithPassphrase(pem, []byte(config.SSHKeyPassphrase))
+		} else {
+			signer, err = ssh.ParsePrivateKey(pem)
+		}
+		if nil != err {
+			return nil, fmt.Errorf("解析 SSH 私钥失败: %w", err)
+		}
+		authList = append(authList, ssh.PublicKeys(signer))
+	}
+	if "" != config.SSHPassword {
+		authList = append(authList, ssh.Password(config.SSHPassword))
+	}
+	if 0 == len(authList) {
+		return nil, errors.New("SSH 私钥与密码至少需要指定一项")
+	}
+
+	// 主机密钥校验
+	hostKeyCallback := ssh.InsecureIgnoreHostKey()
+	if "" != config.SSHKnownHosts {
+		callback, err := knownhosts.New(config.SSHKnownHosts)
+		if nil != err {
+			return nil, fmt.Errorf("读取 known_hosts 失败: %w", err)
+		}
+		hostKeyCallback = callback
+	} else {
+		slog.Warn("未指定 known_hosts，跳过 SSH 主机密钥校验", "ssh", config.SSHHost)
+	}
+
+	return &ssh.ClientConfig{
+		User:            config.SSHUser,
+		Auth:            authList,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         sshDialTimeout,
+	}, nil
+}
@@ -0,0 +1,312 @@
+package configs
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"golang.org/x/crypto/ssh"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const (
+	testSSHUser     = "tunnel"
+	testSSHPassword = "secret"
+)
+
+// directTCPIPPayload direct-tcpip 通道的请求数据（RFC 4254 7.2）
+type directTCPIPPayload struct {
+	Host       string
+	Port       uint32
+	OriginHost string
+	OriginPort uint32
+}
+
+// startTestSSHServer 启动进程内的 SSH 服务，只处理 direct-tcpip 端口转发；返回地址及已打开的转发通道数
+func startTestSSHServer(t *testing.T) (string, *atomic.Int32) {
+	t.Helper()
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+	hostKey, err := ssh.NewSignerFromKey(privateKey)
+	if nil != err {
+		t.Fatal(err)
+	}
+	serverConfig := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if testSSHUser == conn.User() && testSSHPassword == string(password) {
+				return nil, nil
+			}
+			return nil, errors.New("access denied")
+		},
+	}
+	serverConfig.AddHostKey(hostKey)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if nil != err {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	channelCount := &atomic.Int32{}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if nil != err {
+				return
+			}
+			go serveTestSSHConn(conn, serverConfig, channelCount)
+		}
+	}()
+	return listener.Addr().String(), channelCount
+}
+
+// serveTestSSHConn 处理单个 SSH 连接
+func serveTestSSHConn(conn net.Conn, serverConfig *ssh.ServerConfig, channelCount *atomic.Int32) {
+	serverConn, channelList, requestList, err := ssh.NewServerConn(conn, serverConfig)
+	if nil != err {
+		_ = conn.Close()
+		return
+	}
+	defer serverConn.Close()
+	go ssh.DiscardRequests(requestList)
+
+	for newChannel := range channelList {
+		if "direct-tcpip" != newChannel.ChannelType() {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+		payload := &directTCPIPPayload{}
+		if err = ssh.Unmarshal(newChannel.ExtraData(), payload); nil != err {
+			_ = newChannel.Reject(ssh.ConnectionFailed, "invalid payload")
+			continue
+		}
+		targetConn, err := net.Dial("tcp", net.JoinHostPort(payload.Host, strconv.Itoa(int(payload.Port))))
+		if nil != err {
+			_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		channel, channelRequestList, err := newChannel.Accept()
+		if nil != err {
+			_ = targetConn.Close()
+			continue
+		}
+		channelCount.Add(1)
+		go ssh.DiscardRequests(channelRequestList)
+		go func() {
+			_, _ = io.Copy(channel, targetConn)
+			_ = channel.Close()
+		}()
+		go func() {
+			_, _ = io.Copy(targetConn, channel)
+			_ = targetConn.Close()
+		}()
+	}
+}
+
+// startEchoServer 启动回显服务，代替数据库
+func startEchoServer(t *testing.T) int {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if nil != err {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if nil != err {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+// assertEcho 写入后读取相同内容
+func assertEcho(t *testing.T, conn net.Conn, text string) {
+	t.Helper()
+	if _, err := conn.Write([]byte(text)); nil != err {
+		t.Fatal(err)
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buf := make([]byte, len(text))
+	if _, err := io.ReadFull(conn, buf); nil != err {
+		t.Fatal(err)
+	}
+	if text != string(buf) {
+		t.Fatalf("echo = %q, want %q", buf, text)
+	}
+}
+
+func TestSSHTunnel(t *testing.T) {
+	sshAddr, channelCount := startTestSSHServer(t)
+	sshHost, sshPortText, _ := net.SplitHostPort(sshAddr)
+	sshPort, _ := strconv.Atoi(sshPortText)
+	echoPort := startEchoServer(t)
+
+	tunnel, err := OpenSSHTunnel(&DatabaseConfig{
+		Type:        "MySQL",
+		Host:        "127.0.0.1",
+		Port:        echoPort,
+		SSHHost:     sshHost,
+		SSHPort:     sshPort,
+		SSHUser:     testSSHUser,
+		SSHPassword: testSSHPassword,
+	})
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	// 多个连接分别转发
+	localAddr := net.JoinHostPort("127.0.0.1", strconv.Itoa(tunnel.LocalPort()))
+	connList := []net.Conn{}
+	for _, text := range []string{"hello", "world"} {
+		conn, err := net.Dial("tcp", localAddr)
+		if nil != err {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		assertEcho(t, conn, text)
+		connList = append(connList, conn)
+	}
+	if 2 != channelCount.Load() {
+		t.Errorf("channel count = %d, want 2", channelCount.Load())
+	}
+
+	// 连接未断开时关闭隧道，Close 应等待转发结束后返回
+	closed := make(chan error, 1)
+	go func() { closed <- tunnel.Close() }()
+	select {
+	case err = <-closed:
+		if nil != err {
+			t.Fatal(err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Close did not return")
+	}
+
+	// Close 返回时转发协程已关闭本地连接，读取立即得到 EOF 而不是超时
+	for _, conn := range connList {
+		_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+		if _, err = conn.Read(make([]byte, 1)); !errors.Is(err, io.EOF) {
+			t.Errorf("read after Close: err = %v, want EOF", err)
+		}
+	}
+	// 本地端口已不再监听
+	if conn, err := net.DialTimeout("tcp", localAddr, time.Second); nil == err {
+		_ = conn.Close()
+		t.Error("local port still accepts connections after Close")
+	}
+}
+
+// trackedListener 记录接受的本地连接，用于检查 Close 返回时连接是否已由转发协程关闭
+type trackedListener struct {
+	net.Listener
+	mutex    sync.Mutex
+	connList []*trackedConn
+}
+
+func (this *trackedListener) Accept() (net.Conn, error) {
+	conn, err := this.Listener.Accept()
+	if nil != err {
+		return nil, err
+	}
+	tracked := &trackedConn{Conn: conn}
+	this.mutex.Lock()
+	this.connList = append(this.connList, tracked)
+	this.mutex.Unlock()
+	return tracked, nil
+}
+
+// trackedConn 记录是否已关闭
+type trackedConn struct {
+	net.Conn
+	closed atomic.Bool
+}
+
+func (this *trackedConn) Close() error {
+	this.closed.Store(true)
+	return this.Conn.Close()
+}
+
+func TestSSHTunnelCloseWaitsForward(t *testing.T) {
+	sshAddr, _ := startTestSSHServer(t)
+	echoPort := startEchoServer(t)
+
+	// 与 OpenSSHTunnel 相同的方式创建隧道，监听替换为 trackedListener
+	client, err := ssh.Dial("tcp", sshAddr, &ssh.ClientConfig{
+		User:            testSSHUser,
+		Auth:            []ssh.AuthMethod{ssh.Password(testSSHPassword)},
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+	})
+	if nil != err {
+		t.Fatal(err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if nil != err {
+		t.Fatal(err)
+	}
+	tracked := &trackedListener{Listener: listener}
+	tunnel := &SSHTunnel{
+		client:     client,
+		listener:   tracked,
+		remoteAddr: net.JoinHostPort("127.0.0.1", strconv.Itoa(echoPort)),
+	}
+	tunnel.waitGroup.Add(1)
+	go tunnel.accept()
+
+	localAddr := net.JoinHostPort("127.0.0.1", strconv.Itoa(tunnel.LocalPort()))
+	for i := 0; i < 3; i++ {
+		conn, err := net.Dial("tcp", localAddr)
+		if nil != err {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		assertEcho(t, conn, strconv.Itoa(i))
+	}
+	if err = tunnel.Close(); nil != err {
+		t.Fatal(err)
+	}
+
+	// 不等待，Close 返回时全部转发协程已结束并关闭了本地连接
+	tracked.mutex.Lock()
+	defer tracked.mutex.Unlock()
+	if 3 != len(tracked.connList) {
+		t.Fatalf("accepted %d connections, want 3", len(tracked.connList))
+	}
+	for i, conn := range tracked.connList {
+		if !conn.closed.Load() {
+			t.Errorf("connection %d not closed when Close returned", i)
+		}
+	}
+}
+
+func TestSSHTunnelAuthFailed(t *testing.T) {
+	sshAddr, _ := startTestSSHServer(t)
+	sshHost, sshPortText, _ := net.SplitHostPort(sshAddr)
+	sshPort, _ := strconv.Atoi(sshPortText)
+
+	_, err := OpenSSHTunnel(&DatabaseConfig{
+		Type:        "MySQL",
+		Host:        "127.0.0.1",
+		Port:        3306,
+		SSHHost:     sshHost,
+		SSHPort:     sshPort,
+		SSHUser:     testSSHUser,
+		SSHPassword: "wrong",
+	})
+	if nil == err {
+		t.Fatal("OpenSSHTunnel succeeded with a wrong password")
+	}
+}
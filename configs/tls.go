@@ -0,0 +1,215 @@
+package configs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"errors"
+	"fmt"
+	clickhouseDriver "github.com/ClickHouse/clickhouse-go/v2"
+	mysqlDriver "github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	mssql "github.com/microsoft/go-mssqldb"
+	"github.com/microsoft/go-mssqldb/msdsn"
+	"gorm.io/driver/clickhouse"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlserver"
+	"gorm.io/gorm"
+	"log/slog"
+	"os"
+	"strconv"
+)
+
+// TLS/SSL 模式，语义与 libpq 的 sslmode 保持一致
+const (
+	// SSLModeDisable 不使用 TLS
+	SSLModeDisable = "disable"
+	// SSLModeRequire 使用 TLS，但不校验服务器证书
+	SSLModeRequire = "require"
+	// SSLModeVerifyCA 校验服务器证书链，但不校验主机名
+	SSLModeVerifyCA = "verify-ca"
+	// SSLModeVerifyFull 校验服务器证书链与主机名
+	SSLModeVerifyFull = "verify-full"
+)
+
+// SSLModeList 可选的 TLS/SSL 模式
+var SSLModeList = []string{SSLModeDisable, SSLModeRequire, SSLModeVerifyCA, SSLModeVerifyFull}
+
+// mysqlTLSConfigName 注册到 MySQL 驱动的 TLS 配置名
+const mysqlTLSConfigName = "gendict"
+
+// IsTLSEnabled 是否启用 TLS（未指定模式时沿用驱动默认行为）
+func (this *DatabaseConfig) IsTLSEnabled() bool {
+	return "" != this.SSLMode && SSLModeDisable != this.SSLMode
+}
+
+// BuildTLSConfig 根据 SSL 配置创建 tls.Config，未启用 TLS 时返回 nil
+func BuildTLSConfig(config *DatabaseConfig) (*tls.Config, error) {
+	if !config.IsTLSEnabled() {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName: config.SSLServerName,
+	}
+	if "" == tlsConfig.ServerName {
+		tlsConfig.ServerName = config.Host
+	}
+
+	// CA 证书，未指定时使用系统证书
+	if "" != config.SSLRootCert {
+		pem, err := os.ReadFile(config.SSLRootCert)
+		if nil != err {
+			return nil, fmt.Errorf("读取 CA 证书失败: %w", err)
+		}
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA 证书格式错误: %s", config.SSLRootCert)
+		}
+		tlsConfig.RootCAs = certPool
+	}
+
+	// 客户端证书
+	if "" != config.SSLCert || "" != config.SSLKey {
+		if "" == config.SSLCert || "" == config.SSLKey {
+			return nil, errors.New("客户端证书与私钥需同时指定")
+		}
+		cert, err := tls.LoadX509KeyPair(config.SSLCert, config.SSLKey)
+		if nil != err {
+			return nil, fmt.Errorf("读取客户端证书失败: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	switch config.SSLMode {
+	case SSLModeRequire:
+		tlsConfig.InsecureSkipVerify = true
+	case SSLModeVerifyCA:
+		// 跳过默认校验（含主机名），自行校验证书链
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyPeerCertificate = newVerifyCAFunc(tlsConfig.RootCAs)
+	case SSLModeVerifyFull:
+	default:
+		return nil, fmt.Errorf("unsupported ssl mode: %s", config.SSLMode)
+	}
+
+	return tlsConfig, nil
+}
+
+// newVerifyCAFunc 创建只校验证书链、不校验主机名的校验函数
+func newVerifyCAFunc(rootCAs *x509.CertPool) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if 0 == len(rawCerts) {
+			return errors.New("服务器未提供证书")
+		}
+
+		certList := make([]*x509.Certificate, 0, len(rawCerts))
+		for _, rawCert := range rawCerts {
+			cert, err := x509.ParseCertificate(rawCert)
+			if nil != err {
+				return err
+			}
+			certList = append(certList, cert)
+		}
+
+		intermediates := x509.NewCertPool()
+		for _, cert := range certList[1:] {
+			intermediates.AddCert(cert)
+		}
+		_, err := certList[0].Verify(x509.VerifyOptions{
+			Roots:         rootCAs,
+			Intermediates: intermediates,
+		})
+		return err
+	}
+}
+
+// registerMySQLTLSConfig 向 MySQL 驱动注册 TLS 配置，返回 DSN 中 tls 参数的值
+func registerMySQLTLSConfig(config *DatabaseConfig) (string, error) {
+	tlsConfig, err := BuildTLSConfig(config)
+	if nil != err {
+		return "", err
+	}
+	if err = mysqlDriver.RegisterTLSConfig(mysqlTLSConfigName, tlsConfig); nil != err {
+		return "", err
+	}
+	return mysqlTLSConfigName, nil
+}
+
+// openPostgres 打开 PostgreSQL，启用 TLS 时替换 pgx 的 TLS 配置
+func openPostgres(dsn string, config *DatabaseConfig) (gorm.Dialector, error) {
+	tlsConfig, err := BuildTLSConfig(config)
+	if nil != err {
+		return nil, err
+	}
+	if nil == tlsConfig {
+		return postgres.Open(dsn), nil
+	}
+
+	connConfig, err := pgx.ParseConfig(dsn)
+	if nil != err {
+		return nil, err
+	}
+	connConfig.TLSConfig = tlsConfig
+	connConfig.Fallbacks = nil
+	return postgres.New(postgres.Config{Conn: stdlib.OpenDB(*connConfig)}), nil
+}
+
+// openSqlServer 打开 SQL Server，启用 TLS 时强制加密并替换 TLS 配置
+func openSqlServer(dsn string, config *DatabaseConfig) (gorm.Dialector, error) {
+	tlsConfig, err := BuildTLSConfig(config)
+	if nil != err {
+		return nil, err
+	}
+	if nil == tlsConfig {
+		return sqlserver.Open(dsn), nil
+	}
+
+	msConfig, _, err := msdsn.Parse(dsn)
+	if nil != err {
+		return nil, err
+	}
+	msConfig.Encryption = msdsn.EncryptionRequired
+	msConfig.TLSConfig = tlsConfig
+	msConfig.HostInCertificateProvided = "" != config.SSLServerName
+	return sqlserver.New(sqlserver.Config{Conn: sql.OpenDB(mssql.NewConnectorConfig(msConfig))}), nil
+}
+
+// openClickHouse 打开 ClickHouse，启用 TLS 时替换 TLS 配置
+func openClickHouse(dsn string, config *DatabaseConfig) (gorm.Dialector, error) {
+	tlsConfig, err := BuildTLSConfig(config)
+	if nil != err {
+		return nil, err
+	}
+	if nil == tlsConfig {
+		return clickhouse.Open(dsn), nil
+	}
+
+	options, err := clickhouseDriver.ParseDSN(dsn)
+	if nil != err {
+		return nil, err
+	}
+	options.TLS = tlsConfig
+	return clickhouse.New(clickhouse.Config{Conn: clickhouseDriver.OpenDB(options)}), nil
+}
+
+// buildOracleOptions 生成 Oracle 连接选项，go-ora 仅支持通过 wallet 提供证书
+func buildOracleOptions(config *DatabaseConfig) map[string]string {
+	defaultParams := map[string]string{}
+	if SSLModeDisable == config.SSLMode {
+		defaultParams["SSL"] = "false"
+	} else if config.IsTLSEnabled() {
+		defaultParams["SSL"] = "true"
+		defaultParams["SSL VERIFY"] = strconv.FormatBool(SSLModeRequire != config.SSLMode)
+		if "" != config.SSLRootCert {
+			defaultParams["WALLET"] = config.SSLRootCert
+		}
+		if "" != config.SSLCert || "" != config.SSLServerName {
+			slog.Warn("Oracle 不支持单独指定客户端证书或服务器名，请使用 wallet", "wallet", config.SSLRootCert)
+		}
+	}
+
+	_, options := mergeParams(defaultParams, config.Params)
+	return options
+}
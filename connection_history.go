@@ -0,0 +1,181 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"goDict/configs"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+// 最近连接保留数量（不含收藏）
+const connectionHistoryLimit = 10
+
+// 连接历史在 Preferences 中的键
+const connectionHistoryPreferenceKey = "main-view.connection.history"
+
+// ConnectionProfile 保存的连接，不含明文密码（密码由加密凭据库保存）
+type ConnectionProfile struct {
+	// 收藏名称
+	Name      string `json:"name"`
+	Favourite bool   `json:"favourite"`
+	// 连接串模式下的连接串（仅在不含密码时保存）
+	Dsn          string                 `json:"dsn"`
+	Config       configs.DatabaseConfig `json:"config"`
+	OutputFormat string                 `json:"outputFormat"`
+	OutputDir    string                 `json:"outputDir"`
+	LastUsedAt   time.Time              `json:"lastUsedAt"`
+}
+
+// NewConnectionProfile 创建连接记录，清除配置中的明文密码
+func NewConnectionProfile(dbConfig *configs.DatabaseConfig, dsn string, outputFormat string, outputDir string) *ConnectionProfile {
+	profile := &ConnectionProfile{
+		Config:       *dbConfig,
+		OutputFormat: outputFormat,
+		OutputDir:    outputDir,
+		LastUsedAt:   time.Now(),
+	}
+	profile.Config.Password = ""
+	profile.Config.SSHPassword = ""
+	profile.Config.SSHKeyPassphrase = ""
+
+	// 连接串中含有密码时不保存，恢复时使用解析后的表单
+	if "" != dsn {
+		if parsedConfig, err := configs.ParseDSN(dsn); nil == err && "" == parsedConfig.Password {
+			profile.Dsn = dsn
+		}
+	}
+	return profile
+}
+
+// Key 连接的唯一标识
+func (this *ConnectionProfile) Key() string {
+	return configs.CredentialKey(&this.Config)
+}
+
+// GetTitle 下拉框中显示的标题
+func (this *ConnectionProfile) GetTitle() string {
+	var title string
+	switch this.Config.Type {
+	case "SQLite", "DuckDB":
+		title = fmt.Sprintf("%s %s", this.Config.Type, this.Config.Host)
+	default:
+		title = fmt.Sprintf("%s %s@%s:%d/%s", this.Config.Type, this.Config.Username, this.Config.Host, this.Config.Port, this.Config.Database)
+	}
+
+	if this.Favourite {
+		if "" != this.Name {
+			return fmt.Sprintf("★ %s (%s)", this.Name, title)
+		}
+		return "★ " + title
+	}
+	return title
+}
+
+// ConnectionHistory 连接历史及收藏，保存在 Fyne Preferences 中
+type ConnectionHistory struct {
+	preferences fyne.Preferences
+	profileList []*ConnectionProfile
+}
+
+// NewConnectionHistory 创建连接历史并读取已保存的记录
+func NewConnectionHistory(preferences fyne.Preferences) *ConnectionHistory {
+	instance := &ConnectionHistory{preferences: preferences}
+	instance.load()
+	return instance
+}
+
+// GetProfileList 收藏在前，其余按最近使用时间倒序
+func (this *ConnectionHistory) GetProfileList() []*ConnectionProfile {
+	return this.profileList
+}
+
+// Add 添加或更新连接记录，保留原有的收藏状态和名称
+func (this *ConnectionHistory) Add(profile *ConnectionProfile) {
+	for i, item := range this.profileList {
+		if item.Key() == profile.Key() {
+			profile.Favourite = item.Favourite
+			profile.Name = item.Name
+			this.profileList = append(this.profileList[:i], this.profileList[i+1:]...)
+			break
+		}
+	}
+	this.profileList = append(this.profileList, profile)
+
+	this.sort()
+	this.trim()
+	this.save()
+}
+
+// SetFavourite 收藏或取消收藏，并设置名称
+func (this *ConnectionHistory) SetFavourite(profile *ConnectionProfile, favourite bool, name string) {
+	profile.Favourite = favourite
+	profile.Name = name
+
+	this.sort()
+	this.trim()
+	this.save()
+}
+
+// Remove 删除连接记录
+func (this *ConnectionHistory) Remove(profile *ConnectionProfile) {
+	for i, item := range this.profileList {
+		if item == profile {
+			this.profileList = append(this.profileList[:i], this.profileList[i+1:]...)
+			break
+		}
+	}
+	this.save()
+}
+
+// sort 收藏在前，其余按最近使用时间倒序
+func (this *ConnectionHistory) sort() {
+	sort.SliceStable(this.profileList, func(i, j int) bool {
+		if this.profileList[i].Favourite != this.profileList[j].Favourite {
+			return this.profileList[i].Favourite
+		}
+		return this.profileList[i].LastUsedAt.After(this.profileList[j].LastUsedAt)
+	})
+}
+
+// trim 只保留最近的若干条非收藏记录
+func (this *ConnectionHistory) trim() {
+	result := make([]*ConnectionProfile, 0, len(this.profileList))
+	recentCount := 0
+	for _, profile := range this.profileList {
+		if !profile.Favourite {
+			if recentCount >= connectionHistoryLimit {
+				continue
+			}
+			recentCount++
+		}
+		result = append(result, profile)
+	}
+	this.profileList = result
+}
+
+// load 从 Preferences 读取
+func (this *ConnectionHistory) load() {
+	text := this.preferences.String(connectionHistoryPreferenceKey)
+	if "" == text {
+		return
+	}
+	if err := json.Unmarshal([]byte(text), &this.profileList); nil != err {
+		slog.Warn("读取连接历史失败", "error", err)
+		this.profileList = nil
+		return
+	}
+	this.sort()
+}
+
+// save 写入 Preferences
+func (this *ConnectionHistory) save() {
+	bytes, err := json.Marshal(this.profileList)
+	if nil != err {
+		slog.Warn("保存连接历史失败", "error", err)
+		return
+	}
+	this.preferences.SetString(connectionHistoryPreferenceKey, string(bytes))
+}
@@ -2,10 +2,9 @@ package dao
 
 import (
 	"context"
-	"fmt"
 	"goDict/models"
-	"goDict/utils"
 	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 	"log/slog"
 	"reflect"
 	"time"
@@ -14,12 +13,16 @@ import (
 // IBaseDao 泛型DAO接口
 type IBaseDao[T models.IEntity] interface {
 	Insert(ctx context.Context, entity *T) error
+	InsertBatch(ctx context.Context, entities []*T, batchSize int) error
+	Upsert(ctx context.Context, entity *T, conflictColumns ...string) error
 	DeleteById(ctx context.Context, id uint) error
 	DeleteByIdList(ctx context.Context, ids []uint) error
 	Update(ctx context.Context, entity *T) error
 	SelectById(ctx context.Context, id uint) (*T, error)
 	SelectByIdList(ctx context.Context, ids []uint, queryOptions *models.QueryOption) (*models.QueryResult[T], error)
 	SelectByQuery(ctx context.Context, query *T, queryOptions *models.QueryOption) (*models.QueryResult[T], error)
+	SelectByCursor(ctx context.Context, query *T, queryOptions *models.QueryOption) (*models.CursorQueryResult[T], error)
+	ForEach(ctx context.Context, query *T, queryOptions *models.QueryOption, fn func(entity *T) error) error
 	WithTransaction(ctx context.Context, txFunc func(txCtx context.Context) error) error
 	GetTableName() string
 	GetDb() *gorm.DB
@@ -67,8 +70,8 @@ func GetTableName(entity interface{}) string {
 	// 获取类型名称作为表名（小写并复数化）
 	typeName := val.Type().Name()
 	if len(typeName) > 0 {
-		// 简单的复数化规则（实际应用中可能需要更复杂的规则）
-		return fmt.Sprintf("%ss", utils.ToSnakeCase(typeName))
+		// 与 gorm 默认的命名规则一致（蛇形、复数）
+		return schema.NamingStrategy{}.TableName(typeName)
 	}
 
 	return "" // 默认表名
@@ -79,36 +82,54 @@ func (d *BaseDao[T]) GetDb() *gorm.DB {
 	return d.db
 }
 
-// Insert 插入记录
+// Insert 插入记录，模型包含 created_by、updated_by 列时使用上下文中的用户填充
 func (d *BaseDao[T]) Insert(ctx context.Context, entity *T) error {
-	result := d.db.WithContext(ctx).Create(entity)
+	modelSchema, err := d.getSchema()
+	if nil != err {
+		return err
+	}
+	if err = fillAuditFields(ctx, modelSchema, reflect.ValueOf(entity).Elem(), true); nil != err {
+		return err
+	}
+	result := d.getDb(ctx).Create(entity)
 	return result.Error
 }
 
-// DeleteById 根据ID删除
+// DeleteById 根据ID删除，模型包含 gorm.DeletedAt 字段（如嵌入 models.SoftDeleteModel）时为软删除
 func (d *BaseDao[T]) DeleteById(ctx context.Context, id uint) error {
 	var entity T
-	result := d.db.WithContext(ctx).Where("id = ?", id).Delete(&entity)
+	result := d.getDb(ctx).Where("id = ?", id).Delete(&entity)
 	return result.Error
 }
 
-// DeleteByIdList 根据ID列表批量删除
+// DeleteByIdList 根据ID列表批量删除，软删除同 DeleteById
 func (d *BaseDao[T]) DeleteByIdList(ctx context.Context, ids []uint) error {
 	var entity T
-	result := d.db.WithContext(ctx).Where("id IN ?", ids).Delete(&entity)
+	result := d.getDb(ctx).Where("id IN ?", ids).Delete(&entity)
 	return result.Error
 }
 
-// Update 更新记录
+// Update 更新记录，模型包含 updated_by 列时使用上下文中的用户填充；
+// 包含整数类型的 version 列时使用乐观锁，版本号与数据库不一致时返回 ErrOptimisticLock
 func (d *BaseDao[T]) Update(ctx context.Context, entity *T) error {
-	result := d.db.WithContext(ctx).Save(entity)
+	modelSchema, err := d.getSchema()
+	if nil != err {
+		return err
+	}
+	if err = fillAuditFields(ctx, modelSchema, reflect.ValueOf(entity).Elem(), false); nil != err {
+		return err
+	}
+	if versionField := getVersionField(modelSchema); nil != versionField {
+		return updateWithVersion(ctx, d.getDb(ctx), modelSchema, versionField, entity)
+	}
+	result := d.getDb(ctx).Save(entity)
 	return result.Error
 }
 
 // SelectById 根据ID查询
 func (d *BaseDao[T]) SelectById(ctx context.Context, id uint) (*T, error) {
 	var entity T
-	result := d.db.WithContext(ctx).First(&entity, id)
+	result := d.getDb(ctx).First(&entity, id)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -120,13 +141,24 @@ func (d *BaseDao[T]) SelectByIdList(ctx context.Context, ids []uint, queryOption
 	var entities []*T
 
 	// 构建基础查询
-	db := d.db.WithContext(ctx).Table(d.tableName).Where("id IN ?", ids)
+	db := d.getDb(ctx).Model(new(T)).Table(d.tableName).Where("id IN ?", ids)
+
+	// 过滤条件
+	db, err := d.applyFilter(db, queryOptions)
+	if nil != err {
+		return nil, err
+	}
+
+	// 获取总记录数，与数据使用相同的条件
+	totalCount, err := countByQuery(db, queryOptions)
+	if nil != err {
+		return nil, err
+	}
 
 	// 处理排序
-	if nil != queryOptions && 0 < len(queryOptions.Sorting) {
-		for _, sorting := range queryOptions.Sorting {
-			db = db.Order(sorting)
-		}
+	db, err = d.applySorting(db, queryOptions)
+	if nil != err {
+		return nil, err
 	}
 
 	// 处理分页
@@ -145,21 +177,13 @@ func (d *BaseDao[T]) SelectByIdList(ctx context.Context, ids []uint, queryOption
 		return nil, result.Error
 	}
 
-	// 获取总记录数
-	var totalCount int64
-	countDb := d.db.WithContext(ctx).Table(d.tableName).Where("id IN ?", ids)
-	countResult := countDb.Count(&totalCount)
-	if nil != countResult.Error {
-		return nil, countResult.Error
-	}
-
 	// 生成查询结果
 	queryResult := models.NewQueryResult(entities, totalCount, queryOptions)
 
 	return queryResult, nil
 }
 
-// SelectByQuery 根据条件查询
+// SelectByQuery 根据条件查询，query 中的零值字段被忽略，其他条件使用 queryOptions.Filter
 func (d *BaseDao[T]) SelectByQuery(ctx context.Context, query *T, queryOptions *models.QueryOption) (*models.QueryResult[T], error) {
 	// 如果ctx为nil，使用Background作为默认上下文
 	if ctx == nil {
@@ -176,18 +200,29 @@ func (d *BaseDao[T]) SelectByQuery(ctx context.Context, query *T, queryOptions *
 	var entities []*T
 
 	// 构建基础查询
-	db := d.db.WithContext(ctx).Table(d.tableName)
+	db := d.getDb(ctx).Model(new(T)).Table(d.tableName)
 
 	// 查询条件
 	if nil != query {
 		db = db.Where(query)
 	}
 
+	// 过滤条件
+	db, err := d.applyFilter(db, queryOptions)
+	if nil != err {
+		return nil, err
+	}
+
+	// 获取总记录数，与数据使用相同的条件
+	totalCount, err := countByQuery(db, queryOptions)
+	if nil != err {
+		return nil, err
+	}
+
 	// 处理排序
-	if nil != queryOptions && 0 < len(queryOptions.Sorting) {
-		for _, sorting := range queryOptions.Sorting {
-			db = db.Order(sorting)
-		}
+	db, err = d.applySorting(db, queryOptions)
+	if nil != err {
+		return nil, err
 	}
 
 	// 处理分页
@@ -213,32 +248,28 @@ func (d *BaseDao[T]) SelectByQuery(ctx context.Context, query *T, queryOptions *
 		return nil, result.Error
 	}
 
-	// 获取总记录数
-	var totalCount int64
-	countDb := d.db.WithContext(ctx).Table(d.tableName).Where(query)
-	countResult := countDb.Count(&totalCount)
-	if nil != countResult.Error {
-		return nil, countResult.Error
-	}
-
 	// 生成查询结果
 	queryResult := models.NewQueryResult(entities, totalCount, queryOptions)
 
 	return queryResult, nil
 }
 
-// WithTransaction 事务支持
-func (d *BaseDao[T]) WithTransaction(ctx context.Context, txFunc func(txCtx context.Context) error) error {
-	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		txCtx := context.WithValue(ctx, "tx", tx)
-		return txFunc(txCtx)
-	})
+// countByQuery 统计 db 中条件（不含排序、分页）的记录数，queryOptions.SkipCount 时不统计，返回 nil
+func countByQuery(db *gorm.DB, queryOptions *models.QueryOption) (*int64, error) {
+	if nil != queryOptions && queryOptions.SkipCount {
+		return nil, nil
+	}
+	totalCount := new(int64)
+	if err := db.Session(&gorm.Session{}).Count(totalCount).Error; nil != err {
+		return nil, err
+	}
+	return totalCount, nil
 }
 
-// GetDbFromContext 从上下文中获取数据库实例（支持事务）
-func GetDbFromContext(ctx context.Context, defaultDb *gorm.DB) *gorm.DB {
-	if tx, ok := ctx.Value("tx").(*gorm.DB); ok {
-		return tx
-	}
-	return defaultDb
+// WithTransaction 在事务中执行 txFunc，返回错误或 panic 时回滚，否则提交；
+// 上下文中已有事务时使用保存点嵌套
+func (d *BaseDao[T]) WithTransaction(ctx context.Context, txFunc func(txCtx context.Context) error) error {
+	return d.getDb(ctx).Transaction(func(tx *gorm.DB) error {
+		return txFunc(contextWithTx(ctx, tx, "", true))
+	})
 }
@@ -0,0 +1,96 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"goDict/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	"reflect"
+)
+
+// 审计及乐观锁的列名，与 models.AuditModel、models.VersionModel 一致，未嵌入时按列名生效
+const (
+	createdByColumnName = "created_by"
+	updatedByColumnName = "updated_by"
+	versionColumnName   = "version"
+)
+
+// ErrOptimisticLock 乐观锁冲突，记录已被修改（版本号不一致）或已被删除
+var ErrOptimisticLock = errors.New("optimistic lock conflict")
+
+// fillAuditFields 使用上下文中的用户填充 created_by（仅插入且为空时）、updated_by，上下文中没有用户时不修改
+func fillAuditFields(ctx context.Context, modelSchema *schema.Schema, entityValue reflect.Value, create bool) error {
+	user, ok := models.GetUser(ctx)
+	if !ok {
+		return nil
+	}
+	if create {
+		if field := modelSchema.LookUpField(createdByColumnName); nil != field {
+			if _, zero := field.ValueOf(ctx, entityValue); zero {
+				if err := field.Set(ctx, entityValue, user); nil != err {
+					return err
+				}
+			}
+		}
+	}
+	if field := modelSchema.LookUpField(updatedByColumnName); nil != field {
+		if err := field.Set(ctx, entityValue, user); nil != err {
+			return err
+		}
+	}
+	return nil
+}
+
+// getVersionField 乐观锁版本号字段，须为整数类型
+func getVersionField(modelSchema *schema.Schema) *schema.Field {
+	field := modelSchema.LookUpField(versionColumnName)
+	if nil == field {
+		return nil
+	}
+	switch field.IndirectFieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return field
+	}
+	return nil
+}
+
+// updateWithVersion 按主键及原版本号更新全部字段并将版本号加一，未更新到记录时返回 ErrOptimisticLock，失败时恢复版本号
+func updateWithVersion(ctx context.Context, db *gorm.DB, modelSchema *schema.Schema, versionField *schema.Field, entity interface{}) error {
+	entityValue := reflect.Indirect(reflect.ValueOf(entity))
+	// 主键为空时只有版本号条件，会更新其他记录
+	if nil == modelSchema.PrioritizedPrimaryField {
+		return gorm.ErrPrimaryKeyRequired
+	}
+	if _, zero := modelSchema.PrioritizedPrimaryField.ValueOf(ctx, entityValue); zero {
+		return gorm.ErrPrimaryKeyRequired
+	}
+
+	version, _ := versionField.ValueOf(ctx, entityValue)
+	var nextVersion int64 = 1
+	if reflectValue := reflect.Indirect(reflect.ValueOf(version)); reflectValue.IsValid() {
+		if reflectValue.CanInt() {
+			nextVersion = reflectValue.Int() + 1
+		} else {
+			nextVersion = int64(reflectValue.Uint()) + 1
+		}
+	}
+	if err := versionField.Set(ctx, entityValue, nextVersion); nil != err {
+		return err
+	}
+
+	result := db.Model(entity).
+		Where(clause.Eq{Column: clause.Column{Name: versionField.DBName}, Value: version}).
+		Select("*").
+		Updates(entity)
+	if nil == result.Error && 0 == result.RowsAffected {
+		result.Error = ErrOptimisticLock
+	}
+	if nil != result.Error {
+		_ = versionField.Set(ctx, entityValue, version)
+		return result.Error
+	}
+	return nil
+}
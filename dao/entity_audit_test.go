@@ -0,0 +1,175 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"goDict/models"
+	"gorm.io/gorm"
+	"testing"
+)
+
+// auditEntity 测试模型，包含软删除、审计及乐观锁
+type auditEntity struct {
+	models.BaseModel
+	models.SoftDeleteModel
+	models.AuditModel
+	models.VersionModel
+	Name string
+}
+
+func (auditEntity) TableName() string {
+	return "audit_entity"
+}
+
+// openAuditTestDb 创建 audit_entity 表
+func openAuditTestDb(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := openTestDb(t)
+	if err := db.AutoMigrate(&auditEntity{}); nil != err {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestAuditFields(t *testing.T) {
+	db := openAuditTestDb(t)
+	dao := NewBaseDao[auditEntity](db)
+	aliceCtx := models.WithUser(context.Background(), "alice")
+
+	// 插入时填充 created_by、updated_by
+	entity := &auditEntity{Name: "a"}
+	if err := dao.Insert(aliceCtx, entity); nil != err {
+		t.Fatal(err)
+	}
+	if "alice" != entity.CreatedBy || "alice" != entity.UpdatedBy {
+		t.Errorf("insert: created by = %q, updated by = %q, want alice", entity.CreatedBy, entity.UpdatedBy)
+	}
+
+	// 已有 created_by 时不覆盖
+	imported := &auditEntity{Name: "b", AuditModel: models.AuditModel{CreatedBy: "importer"}}
+	if err := dao.Insert(aliceCtx, imported); nil != err {
+		t.Fatal(err)
+	}
+	if "importer" != imported.CreatedBy || "alice" != imported.UpdatedBy {
+		t.Errorf("insert with created by: created by = %q, updated by = %q, want importer and alice", imported.CreatedBy, imported.UpdatedBy)
+	}
+
+	// 上下文中没有用户时不填充
+	anonymous := &auditEntity{Name: "c"}
+	if err := dao.Insert(context.Background(), anonymous); nil != err {
+		t.Fatal(err)
+	}
+	if "" != anonymous.CreatedBy || "" != anonymous.UpdatedBy {
+		t.Errorf("insert without user: created by = %q, updated by = %q, want empty", anonymous.CreatedBy, anonymous.UpdatedBy)
+	}
+
+	// 更新时只填充 updated_by，created_by 不变
+	entity.CreatedBy = ""
+	if err := dao.Update(models.WithUser(context.Background(), "bob"), entity); nil != err {
+		t.Fatal(err)
+	}
+	saved, err := dao.SelectById(context.Background(), entity.ID)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if "" != entity.CreatedBy || "bob" != saved.UpdatedBy {
+		t.Errorf("update: created by = %q, updated by = %q, want empty and bob", entity.CreatedBy, saved.UpdatedBy)
+	}
+}
+
+func TestOptimisticLock(t *testing.T) {
+	db := openAuditTestDb(t)
+	dao := NewBaseDao[auditEntity](db)
+	ctx := context.Background()
+
+	entity := &auditEntity{Name: "a"}
+	if err := dao.Insert(ctx, entity); nil != err {
+		t.Fatal(err)
+	}
+
+	// 更新成功时版本号加一
+	entity.Name = "b"
+	if err := dao.Update(ctx, entity); nil != err {
+		t.Fatal(err)
+	}
+	saved, err := dao.SelectById(ctx, entity.ID)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if 1 != entity.Version || 1 != saved.Version || "b" != saved.Name {
+		t.Errorf("after update: version = %d, saved = %+v, want version 1 and name b", entity.Version, saved)
+	}
+
+	// 两个副本先后更新，后者版本号过期
+	first, err := dao.SelectById(ctx, entity.ID)
+	if nil != err {
+		t.Fatal(err)
+	}
+	second, err := dao.SelectById(ctx, entity.ID)
+	if nil != err {
+		t.Fatal(err)
+	}
+	first.Name = "first"
+	if err = dao.Update(ctx, first); nil != err {
+		t.Fatal(err)
+	}
+	second.Name = "second"
+	if err = dao.Update(ctx, second); !errors.Is(err, ErrOptimisticLock) {
+		t.Fatalf("stale update err = %v, want ErrOptimisticLock", err)
+	}
+	// 失败时恢复内存中的版本号，重新读取后可以再次更新
+	if 1 != second.Version {
+		t.Errorf("stale version = %d, want 1", second.Version)
+	}
+	if saved, err = dao.SelectById(ctx, entity.ID); nil != err || "first" != saved.Name || 2 != saved.Version {
+		t.Errorf("after stale update: saved = %+v, err = %v, want name first and version 2", saved, err)
+	}
+
+	// 主键为空时不更新
+	if err = dao.Update(ctx, &auditEntity{Name: "x"}); !errors.Is(err, gorm.ErrPrimaryKeyRequired) {
+		t.Errorf("update without id err = %v, want ErrPrimaryKeyRequired", err)
+	}
+}
+
+func TestSoftDelete(t *testing.T) {
+	db := openAuditTestDb(t)
+	dao := NewBaseDao[auditEntity](db)
+	ctx := context.Background()
+
+	deleted, kept := &auditEntity{Name: "deleted"}, &auditEntity{Name: "kept"}
+	for _, entity := range []*auditEntity{deleted, kept} {
+		if err := dao.Insert(ctx, entity); nil != err {
+			t.Fatal(err)
+		}
+	}
+	if err := dao.DeleteById(ctx, deleted.ID); nil != err {
+		t.Fatal(err)
+	}
+
+	// 只设置 deleted_at，不删除行
+	var row auditEntity
+	if err := db.Unscoped().First(&row, deleted.ID).Error; nil != err {
+		t.Fatal(err)
+	}
+	if !row.DeletedAt.Valid {
+		t.Error("deleted_at not set")
+	}
+
+	// 查询时忽略已删除的记录
+	queryResult, err := dao.SelectByQuery(ctx, nil, models.NewQueryOption())
+	if nil != err {
+		t.Fatal(err)
+	}
+	if 1 != len(queryResult.Data) || kept.ID != queryResult.Data[0].ID || 1 != *queryResult.TotalCount {
+		t.Errorf("select after delete = %d rows, total count = %d, want only kept", len(queryResult.Data), *queryResult.TotalCount)
+	}
+	if _, err = dao.SelectById(ctx, deleted.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("select deleted err = %v, want ErrRecordNotFound", err)
+	}
+
+	// 已删除的记录不能再更新
+	deleted.Name = "updated"
+	if err = dao.Update(ctx, deleted); !errors.Is(err, ErrOptimisticLock) {
+		t.Errorf("update deleted err = %v, want ErrOptimisticLock", err)
+	}
+}
@@ -0,0 +1,138 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goDict/models"
+	"gorm.io/gorm/clause"
+	"reflect"
+	"slices"
+)
+
+// 批量插入默认每批的行数
+const defaultBatchSize = 100
+
+// ErrUpsertNotSupported 数据库不支持 Upsert，如 ClickHouse
+var ErrUpsertNotSupported = errors.New("upsert not supported")
+
+// Upsert 时不更新的列，保留首次插入的值
+var upsertKeptColumnNameList = []string{"created_at", createdByColumnName, versionColumnName}
+
+// 使用 MERGE 的方言，驱动按主键匹配，不支持其他冲突列
+var mergeDialectNameList = []string{"sqlserver", "oracle"}
+
+// InsertBatch 批量插入，每批 batchSize 行，batchSize 不大于 0 时为 defaultBatchSize；
+// 与 Insert 相同，使用上下文中的用户填充 created_by、updated_by
+func (d *BaseDao[T]) InsertBatch(ctx context.Context, entities []*T, batchSize int) error {
+	if 0 == len(entities) {
+		return nil
+	}
+	if 0 >= batchSize {
+		batchSize = defaultBatchSize
+	}
+	modelSchema, err := d.getSchema()
+	if nil != err {
+		return err
+	}
+	for _, entity := range entities {
+		if err = fillAuditFields(ctx, modelSchema, reflect.ValueOf(entity).Elem(), true); nil != err {
+			return err
+		}
+	}
+	result := d.getDb(ctx).CreateInBatches(entities, batchSize)
+	return result.Error
+}
+
+// Upsert 插入记录，与 conflictColumns（为空时为主键）冲突时更新其他列。
+// 由方言生成对应语句：PostgresSQL、SQLite、DuckDB 为 ON CONFLICT，MySQL 为 ON DUPLICATE KEY UPDATE（冲突列由唯一索引决定），
+// SQLServer、Oracle 为 MERGE（仅支持按主键匹配）；ClickHouse 返回 ErrUpsertNotSupported。
+// created_at、created_by、version 保留原值，不校验乐观锁
+func (d *BaseDao[T]) Upsert(ctx context.Context, entity *T, conflictColumns ...string) error {
+	if "clickhouse" == d.db.Dialector.Name() {
+		return fmt.Errorf("%w: %s", ErrUpsertNotSupported, d.db.Dialector.Name())
+	}
+	modelSchema, err := d.getSchema()
+	if nil != err {
+		return err
+	}
+
+	// 冲突列
+	conflictColumnList := []clause.Column{}
+	for _, name := range conflictColumns {
+		column, err := lookUpColumn(modelSchema, name)
+		if nil != err {
+			return err
+		}
+		conflictColumnList = append(conflictColumnList, column)
+	}
+	if 0 == len(conflictColumnList) {
+		for _, field := range modelSchema.PrimaryFields {
+			conflictColumnList = append(conflictColumnList, clause.Column{Name: field.DBName})
+		}
+	}
+	if 0 == len(conflictColumnList) {
+		return fmt.Errorf("%w: Upsert 需要冲突列或主键", ErrInvalidQueryOption)
+	}
+	if slices.Contains(mergeDialectNameList, d.db.Dialector.Name()) {
+		for _, column := range conflictColumnList {
+			if field := modelSchema.LookUpField(column.Name); !field.PrimaryKey {
+				return fmt.Errorf("%w: %s 只能按主键 Upsert", ErrUpsertNotSupported, d.db.Dialector.Name())
+			}
+		}
+	}
+
+	// 更新的列：除冲突列、主键及保留原值的列以外的全部列
+	updateColumnNameList := []string{}
+	for _, field := range modelSchema.Fields {
+		if "" == field.DBName || !field.Updatable || field.PrimaryKey ||
+			slices.Contains(upsertKeptColumnNameList, field.DBName) ||
+			slices.ContainsFunc(conflictColumnList, func(column clause.Column) bool { return field.DBName == column.Name }) {
+			continue
+		}
+		updateColumnNameList = append(updateColumnNameList, field.DBName)
+	}
+	onConflict := clause.OnConflict{Columns: conflictColumnList, DoNothing: 0 == len(updateColumnNameList)}
+	if !onConflict.DoNothing {
+		onConflict.DoUpdates = clause.AssignmentColumns(updateColumnNameList)
+	}
+
+	if err = fillAuditFields(ctx, modelSchema, reflect.ValueOf(entity).Elem(), true); nil != err {
+		return err
+	}
+	result := d.getDb(ctx).Clauses(onConflict).Create(entity)
+	return result.Error
+}
+
+// ForEach 逐行读取查询结果并调用 fn，不一次加载全部数据，适用于导出等大结果集；
+// 支持 queryOptions 的过滤及排序，忽略分页；fn 返回错误时停止并返回该错误
+func (d *BaseDao[T]) ForEach(ctx context.Context, query *T, queryOptions *models.QueryOption, fn func(entity *T) error) error {
+	db := d.getDb(ctx).Model(new(T)).Table(d.tableName)
+	if nil != query {
+		db = db.Where(query)
+	}
+	db, err := d.applyFilter(db, queryOptions)
+	if nil != err {
+		return err
+	}
+	db, err = d.applySorting(db, queryOptions)
+	if nil != err {
+		return err
+	}
+
+	rows, err := db.Rows()
+	if nil != err {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		entity := new(T)
+		if err = db.ScanRows(rows, entity); nil != err {
+			return err
+		}
+		if err = fn(entity); nil != err {
+			return err
+		}
+	}
+	return rows.Err()
+}
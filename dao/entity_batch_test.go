@@ -0,0 +1,204 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goDict/models"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// batchEntity 测试模型，code 唯一
+type batchEntity struct {
+	ID    uint   `gorm:"primarykey"`
+	Code  string `gorm:"uniqueIndex"`
+	Name  string
+	Price int
+}
+
+func (batchEntity) TableName() string {
+	return "batch_entity"
+}
+
+func (e batchEntity) GetID() uint {
+	return e.ID
+}
+
+// batchTag 测试模型，除主键外只有冲突列，冲突时没有可更新的列
+type batchTag struct {
+	ID   uint   `gorm:"primarykey"`
+	Code string `gorm:"uniqueIndex"`
+}
+
+func (batchTag) TableName() string {
+	return "batch_tag"
+}
+
+func (e batchTag) GetID() uint {
+	return e.ID
+}
+
+// openTestDb 打开内存 SQLite 数据库并建表
+func openTestDb(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	if nil != err {
+		t.Fatal(err)
+	}
+	// 内存库每个连接独立，固定使用一个连接
+	sqlDB, err := db.DB()
+	if nil != err {
+		t.Fatal(err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	if err = db.AutoMigrate(&batchEntity{}, &batchTag{}); nil != err {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestInsertBatch(t *testing.T) {
+	db := openTestDb(t)
+	// 记录每次插入的行数
+	var batchSizeList []int
+	err := db.Callback().Create().After("gorm:create").Register("test:batch_size", func(tx *gorm.DB) {
+		if reflect.Slice == tx.Statement.ReflectValue.Kind() {
+			batchSizeList = append(batchSizeList, tx.Statement.ReflectValue.Len())
+		}
+	})
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	entityList := []*batchEntity{}
+	for i := 0; i < 25; i++ {
+		entityList = append(entityList, &batchEntity{Code: fmt.Sprintf("c%02d", i), Name: "n", Price: i})
+	}
+	dao := NewBaseDao[batchEntity](db)
+	if err = dao.InsertBatch(context.Background(), entityList, 10); nil != err {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal([]int{10, 10, 5}, batchSizeList) {
+		t.Errorf("batch size list = %v, want [10 10 5]", batchSizeList)
+	}
+	var count int64
+	db.Model(&batchEntity{}).Count(&count)
+	if 25 != count {
+		t.Errorf("count = %d, want 25", count)
+	}
+	for _, entity := range entityList {
+		if 0 == entity.ID {
+			t.Fatalf("entity %s has no id after insert", entity.Code)
+		}
+	}
+
+	// 空列表不执行插入
+	batchSizeList = nil
+	if err = dao.InsertBatch(context.Background(), nil, 10); nil != err || 0 != len(batchSizeList) {
+		t.Errorf("empty insert: err = %v, batch size list = %v", err, batchSizeList)
+	}
+}
+
+func TestUpsert(t *testing.T) {
+	db := openTestDb(t)
+	ctx := context.Background()
+
+	// 按唯一列冲突时更新其他列，保留原主键
+	dao := NewBaseDao[batchEntity](db)
+	if err := dao.Insert(ctx, &batchEntity{Code: "a", Name: "old", Price: 1}); nil != err {
+		t.Fatal(err)
+	}
+	if err := dao.Upsert(ctx, &batchEntity{Code: "a", Name: "new", Price: 2}, "code"); nil != err {
+		t.Fatal(err)
+	}
+	var entityList []batchEntity
+	db.Find(&entityList)
+	if 1 != len(entityList) || 1 != entityList[0].ID || "new" != entityList[0].Name || 2 != entityList[0].Price {
+		t.Errorf("after upsert = %+v, want one row {ID:1 Code:a Name:new Price:2}", entityList)
+	}
+
+	// 没有冲突时插入
+	if err := dao.Upsert(ctx, &batchEntity{Code: "b", Name: "b", Price: 3}, "code"); nil != err {
+		t.Fatal(err)
+	}
+	var count int64
+	db.Model(&batchEntity{}).Count(&count)
+	if 2 != count {
+		t.Errorf("count = %d, want 2", count)
+	}
+
+	// 没有可更新的列时冲突不做处理
+	tagDao := NewBaseDao[batchTag](db)
+	if err := tagDao.Insert(ctx, &batchTag{ID: 1, Code: "x"}); nil != err {
+		t.Fatal(err)
+	}
+	if err := tagDao.Upsert(ctx, &batchTag{ID: 2, Code: "x"}, "code"); nil != err {
+		t.Fatal(err)
+	}
+	var tagList []batchTag
+	db.Find(&tagList)
+	if 1 != len(tagList) || 1 != tagList[0].ID {
+		t.Errorf("after upsert = %+v, want one row {ID:1 Code:x}", tagList)
+	}
+
+	// 冲突列必须是模型中的列
+	if err := dao.Upsert(ctx, &batchEntity{Code: "c"}, "no_such_column"); !errors.Is(err, ErrInvalidQueryOption) {
+		t.Errorf("err = %v, want ErrInvalidQueryOption", err)
+	}
+}
+
+func TestForEach(t *testing.T) {
+	db := openTestDb(t)
+	ctx := context.Background()
+	dao := NewBaseDao[batchEntity](db)
+	entityList := []*batchEntity{}
+	for i := 0; i < 10; i++ {
+		entityList = append(entityList, &batchEntity{Code: fmt.Sprintf("c%02d", i), Price: i})
+	}
+	if err := dao.InsertBatch(ctx, entityList, 0); nil != err {
+		t.Fatal(err)
+	}
+
+	// 过滤及排序
+	var priceList []int
+	queryOption := models.NewQueryOption().SetFilter(models.Ge("price", 5)).SetSorting([]string{"price desc"})
+	err := dao.ForEach(ctx, nil, queryOption, func(entity *batchEntity) error {
+		priceList = append(priceList, entity.Price)
+		return nil
+	})
+	if nil != err {
+		t.Fatal(err)
+	}
+	if !slices.Equal([]int{9, 8, 7, 6, 5}, priceList) {
+		t.Errorf("price list = %v, want [9 8 7 6 5]", priceList)
+	}
+
+	// 回调出错时停止并返回该错误
+	errStop := errors.New("stop")
+	visitedCount := 0
+	err = dao.ForEach(ctx, nil, models.NewQueryOption().SetSorting([]string{"id"}), func(entity *batchEntity) error {
+		visitedCount++
+		if 3 == visitedCount {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) {
+		t.Errorf("err = %v, want %v", err, errStop)
+	}
+	if 3 != visitedCount {
+		t.Errorf("visited count = %d, want 3", visitedCount)
+	}
+
+	// 停止后连接已释放，可以继续查询
+	var count int64
+	if err = db.Model(&batchEntity{}).Count(&count).Error; nil != err || 10 != count {
+		t.Errorf("count after stop: count = %d, err = %v", count, err)
+	}
+}
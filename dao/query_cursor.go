@@ -0,0 +1,210 @@
+package dao
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"goDict/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	"reflect"
+	"slices"
+)
+
+// cursor 游标，编码为 base64 的 JSON，对调用方不透明
+type cursor struct {
+	// 排序，校验游标与本次查询的排序一致
+	SortingList []string `json:"s"`
+	// 边界行（上一页的最后一行或第一行）中排序列的值
+	ValueList []json.RawMessage `json:"v"`
+	// 是否向前翻页
+	Prev bool `json:"p,omitempty"`
+}
+
+// encodeCursor 编码游标
+func encodeCursor(c *cursor) (string, error) {
+	content, err := json.Marshal(c)
+	if nil != err {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(content), nil
+}
+
+// decodeCursor 解码游标
+func decodeCursor(token string) (*cursor, error) {
+	content, err := base64.RawURLEncoding.DecodeString(token)
+	if nil != err {
+		return nil, fmt.Errorf("%w: 无效的游标", ErrInvalidQueryOption)
+	}
+	c := &cursor{}
+	if err = json.Unmarshal(content, c); nil != err {
+		return nil, fmt.Errorf("%w: 无效的游标", ErrInvalidQueryOption)
+	}
+	return c, nil
+}
+
+// SelectByCursor 游标分页查询：按排序列（末尾自动追加主键使顺序唯一）定位，不使用 OFFSET。
+// queryOptions.Cursor 为空时查询第一页，PageNum 不生效；排序列的值不能为空
+func (d *BaseDao[T]) SelectByCursor(ctx context.Context, query *T, queryOptions *models.QueryOption) (*models.CursorQueryResult[T], error) {
+	if nil == queryOptions {
+		queryOptions = models.NewQueryOption()
+	}
+	modelSchema, err := d.getSchema()
+	if nil != err {
+		return nil, err
+	}
+
+	// 排序列
+	orderByColumnList, err := buildOrderByColumnList(modelSchema, queryOptions.Sorting)
+	if nil != err {
+		return nil, err
+	}
+	if 0 == len(modelSchema.PrimaryFields) {
+		return nil, fmt.Errorf("%w: 游标分页需要主键", ErrInvalidQueryOption)
+	}
+	for _, field := range modelSchema.PrimaryFields {
+		if !slices.ContainsFunc(orderByColumnList, func(orderByColumn clause.OrderByColumn) bool { return field.DBName == orderByColumn.Column.Name }) {
+			orderByColumnList = append(orderByColumnList, clause.OrderByColumn{Column: clause.Column{Name: field.DBName}})
+		}
+	}
+	sortingList := []string{}
+	for _, orderByColumn := range orderByColumnList {
+		sortingList = append(sortingList, formatOrderByColumn(orderByColumn))
+	}
+
+	// 游标
+	var currentCursor *cursor
+	if "" != queryOptions.Cursor {
+		if currentCursor, err = decodeCursor(queryOptions.Cursor); nil != err {
+			return nil, err
+		}
+		if !slices.Equal(sortingList, currentCursor.SortingList) || len(orderByColumnList) != len(currentCursor.ValueList) {
+			return nil, fmt.Errorf("%w: 游标与排序不一致", ErrInvalidQueryOption)
+		}
+	}
+	prev := nil != currentCursor && currentCursor.Prev
+
+	// 构建基础查询
+	db := d.getDb(ctx).Model(new(T)).Table(d.tableName)
+	if nil != query {
+		db = db.Where(query)
+	}
+	if db, err = d.applyFilter(db, queryOptions); nil != err {
+		return nil, err
+	}
+
+	// 获取总记录数
+	var totalCount *int64
+	if !queryOptions.SkipCount {
+		totalCount = new(int64)
+		if err = db.Session(&gorm.Session{}).Count(totalCount).Error; nil != err {
+			return nil, err
+		}
+	}
+
+	// 游标位置
+	if nil != currentCursor {
+		expression, err := buildCursorExpression(modelSchema, orderByColumnList, currentCursor)
+		if nil != err {
+			return nil, err
+		}
+		db = db.Where(expression)
+	}
+	// 向前翻页时反向排序，查询后再反转
+	for _, orderByColumn := range orderByColumnList {
+		orderByColumn.Desc = orderByColumn.Desc != prev
+		db = db.Order(orderByColumn)
+	}
+
+	// 多查一行，判断是否还有数据
+	pageSize := queryOptions.GetPageSize()
+	var entities []*T
+	if err = db.Limit(pageSize + 1).Find(&entities).Error; nil != err {
+		return nil, err
+	}
+	hasMore := pageSize < len(entities)
+	if hasMore {
+		entities = entities[:pageSize]
+	}
+	if prev {
+		slices.Reverse(entities)
+	}
+
+	// 生成查询结果
+	queryResult := &models.CursorQueryResult[T]{Data: entities, PageSize: pageSize, TotalCount: totalCount}
+	if 0 == len(entities) {
+		return queryResult, nil
+	}
+	// 之后还有数据：向后翻页且多查到一行，或由后一页向前翻页
+	if hasMore || prev {
+		if queryResult.NextCursor, err = buildCursor(ctx, modelSchema, orderByColumnList, sortingList, entities[len(entities)-1], false); nil != err {
+			return nil, err
+		}
+	}
+	// 之前还有数据：向前翻页且多查到一行，或由前一页向后翻页
+	if (prev && hasMore) || (nil != currentCursor && !prev) {
+		if queryResult.PrevCursor, err = buildCursor(ctx, modelSchema, orderByColumnList, sortingList, entities[0], true); nil != err {
+			return nil, err
+		}
+	}
+	return queryResult, nil
+}
+
+// formatOrderByColumn 排序的文本形式，如 "id asc"
+func formatOrderByColumn(orderByColumn clause.OrderByColumn) string {
+	if orderByColumn.Desc {
+		return orderByColumn.Column.Name + " desc"
+	}
+	return orderByColumn.Column.Name + " asc"
+}
+
+// buildCursor 由边界行生成游标
+func buildCursor(ctx context.Context, modelSchema *schema.Schema, orderByColumnList []clause.OrderByColumn, sortingList []string, entity interface{}, prev bool) (string, error) {
+	entityValue := reflect.Indirect(reflect.ValueOf(entity))
+	valueList := []json.RawMessage{}
+	for _, orderByColumn := range orderByColumnList {
+		field := modelSchema.LookUpField(orderByColumn.Column.Name)
+		value, _ := field.ValueOf(ctx, entityValue)
+		if reflectValue := reflect.ValueOf(value); !reflectValue.IsValid() || (reflect.Ptr == reflectValue.Kind() && reflectValue.IsNil()) {
+			return "", fmt.Errorf("%w: 游标分页的排序列 %s 存在空值", ErrInvalidQueryOption, field.DBName)
+		}
+		content, err := json.Marshal(value)
+		if nil != err {
+			return "", err
+		}
+		valueList = append(valueList, content)
+	}
+	return encodeCursor(&cursor{SortingList: sortingList, ValueList: valueList, Prev: prev})
+}
+
+// buildCursorExpression 游标位置之后（向前翻页时为之前）的条件，
+// 如 (k1 > v1) OR (k1 = v1 AND k2 > v2)，降序列使用 <，不依赖行值比较以兼容各数据库
+func buildCursorExpression(modelSchema *schema.Schema, orderByColumnList []clause.OrderByColumn, c *cursor) (clause.Expression, error) {
+	// 游标中的值按字段类型解码，时间等类型才能正确比较
+	valueList := []interface{}{}
+	for i, orderByColumn := range orderByColumnList {
+		field := modelSchema.LookUpField(orderByColumn.Column.Name)
+		value := reflect.New(field.IndirectFieldType)
+		if err := json.Unmarshal(c.ValueList[i], value.Interface()); nil != err {
+			return nil, fmt.Errorf("%w: 无效的游标", ErrInvalidQueryOption)
+		}
+		valueList = append(valueList, value.Elem().Interface())
+	}
+
+	orExpressionList := []clause.Expression{}
+	for i, orderByColumn := range orderByColumnList {
+		andExpressionList := []clause.Expression{}
+		for j := 0; j < i; j++ {
+			andExpressionList = append(andExpressionList, clause.Eq{Column: orderByColumnList[j].Column, Value: valueList[j]})
+		}
+		if orderByColumn.Desc != c.Prev {
+			andExpressionList = append(andExpressionList, clause.Lt{Column: orderByColumn.Column, Value: valueList[i]})
+		} else {
+			andExpressionList = append(andExpressionList, clause.Gt{Column: orderByColumn.Column, Value: valueList[i]})
+		}
+		orExpressionList = append(orExpressionList, clause.And(andExpressionList...))
+	}
+	return clause.Or(orExpressionList...), nil
+}
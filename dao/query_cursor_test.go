@@ -0,0 +1,177 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goDict/models"
+	"slices"
+	"testing"
+)
+
+// cursorEntity 测试模型，price、name 有重复值
+type cursorEntity struct {
+	ID    uint `gorm:"primarykey"`
+	Name  string
+	Price int
+}
+
+func (cursorEntity) TableName() string {
+	return "cursor_entity"
+}
+
+func (e cursorEntity) GetID() uint {
+	return e.ID
+}
+
+// cursorPage 一页的 ID 及游标
+type cursorPage struct {
+	idList     []uint
+	nextCursor string
+	prevCursor string
+}
+
+func TestSelectByCursor(t *testing.T) {
+	db := openTestDb(t)
+	if err := db.AutoMigrate(&cursorEntity{}); nil != err {
+		t.Fatal(err)
+	}
+	entityList := []*cursorEntity{}
+	for i := 0; i < 12; i++ {
+		entityList = append(entityList, &cursorEntity{Name: fmt.Sprintf("n%d", i%3), Price: i % 4})
+	}
+	if err := db.Create(entityList).Error; nil != err {
+		t.Fatal(err)
+	}
+	dao := NewBaseDao[cursorEntity](db)
+	ctx := context.Background()
+	sorting := []string{"price desc", "name asc"}
+
+	// 期望的顺序，主键为最后的排序列
+	var wantIdList []uint
+	if err := db.Model(&cursorEntity{}).Order("price desc, name asc, id asc").Pluck("id", &wantIdList).Error; nil != err {
+		t.Fatal(err)
+	}
+
+	selectPage := func(pageSize int, cursor string) cursorPage {
+		t.Helper()
+		queryResult, err := dao.SelectByCursor(ctx, nil, models.NewQueryOption().SetPageSize(pageSize).SetSorting(sorting).SetCursor(cursor))
+		if nil != err {
+			t.Fatal(err)
+		}
+		if nil == queryResult.TotalCount || 12 != *queryResult.TotalCount {
+			t.Errorf("total count = %v, want 12", queryResult.TotalCount)
+		}
+		page := cursorPage{nextCursor: queryResult.NextCursor, prevCursor: queryResult.PrevCursor}
+		for _, entity := range queryResult.Data {
+			page.idList = append(page.idList, entity.ID)
+		}
+		return page
+	}
+
+	// 最后一页不满及恰好满页
+	for _, pageSize := range []int{5, 4} {
+		t.Run(fmt.Sprintf("page size %d", pageSize), func(t *testing.T) {
+			// 向后翻到最后一页
+			pageList := []cursorPage{selectPage(pageSize, "")}
+			for "" != pageList[len(pageList)-1].nextCursor {
+				pageList = append(pageList, selectPage(pageSize, pageList[len(pageList)-1].nextCursor))
+				if len(wantIdList) < len(pageList) {
+					t.Fatal("too many pages")
+				}
+			}
+			idList := []uint{}
+			for i, page := range pageList {
+				idList = append(idList, page.idList...)
+				// 只有第一页没有上一页
+				if (0 == i) != ("" == page.prevCursor) {
+					t.Errorf("forward page %d: prev cursor = %q", i, page.prevCursor)
+				}
+			}
+			if !slices.Equal(wantIdList, idList) {
+				t.Fatalf("forward id list = %v, want %v", idList, wantIdList)
+			}
+
+			// 由最后一页向前翻到第一页，各页与向后翻页时一致
+			page := pageList[len(pageList)-1]
+			for i := len(pageList) - 2; 0 <= i; i-- {
+				if "" == page.prevCursor {
+					t.Fatalf("backward page %d: no prev cursor", i+1)
+				}
+				page = selectPage(pageSize, page.prevCursor)
+				if !slices.Equal(pageList[i].idList, page.idList) {
+					t.Errorf("backward page %d = %v, want %v", i, page.idList, pageList[i].idList)
+				}
+				// 向前翻页时总有下一页，只有第一页没有上一页
+				if "" == page.nextCursor || (0 == i) != ("" == page.prevCursor) {
+					t.Errorf("backward page %d: next cursor = %q, prev cursor = %q", i, page.nextCursor, page.prevCursor)
+				}
+			}
+
+			// 由向前翻到的页再向后翻页
+			if 1 < len(pageList) {
+				if page = selectPage(pageSize, page.nextCursor); !slices.Equal(pageList[1].idList, page.idList) {
+					t.Errorf("forward again = %v, want %v", page.idList, pageList[1].idList)
+				}
+			}
+		})
+	}
+
+	// 不统计总数
+	queryResult, err := dao.SelectByCursor(ctx, nil, models.NewQueryOption().SetSorting(sorting).SetSkipCount(true))
+	if nil != err || nil != queryResult.TotalCount {
+		t.Errorf("skip count: total count = %v, err = %v, want nil", queryResult.TotalCount, err)
+	}
+}
+
+func TestSelectByCursorInvalid(t *testing.T) {
+	db := openTestDb(t)
+	if err := db.AutoMigrate(&cursorEntity{}); nil != err {
+		t.Fatal(err)
+	}
+	if err := db.Create([]*cursorEntity{{Name: "a", Price: 1}, {Name: "b", Price: 2}, {Name: "c", Price: 3}}).Error; nil != err {
+		t.Fatal(err)
+	}
+	dao := NewBaseDao[cursorEntity](db)
+	ctx := context.Background()
+	sorting := []string{"price desc", "name asc"}
+
+	queryResult, err := dao.SelectByCursor(ctx, nil, models.NewQueryOption().SetPageSize(1).SetSorting(sorting))
+	if nil != err {
+		t.Fatal(err)
+	}
+	validCursor, err := decodeCursor(queryResult.NextCursor)
+	if nil != err {
+		t.Fatal(err)
+	}
+	// 由有效游标修改得到的游标
+	modifyCursor := func(modify func(c *cursor)) string {
+		c := *validCursor
+		c.SortingList = slices.Clone(validCursor.SortingList)
+		c.ValueList = slices.Clone(validCursor.ValueList)
+		modify(&c)
+		token, err := encodeCursor(&c)
+		if nil != err {
+			t.Fatal(err)
+		}
+		return token
+	}
+	// 其他排序生成的游标
+	otherResult, err := dao.SelectByCursor(ctx, nil, models.NewQueryOption().SetPageSize(1).SetSorting([]string{"price asc"}))
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	for name, token := range map[string]string{
+		"not base64":       "!!!",
+		"not json":         "e2JhZA",
+		"other sorting":    otherResult.NextCursor,
+		"sorting modified": modifyCursor(func(c *cursor) { c.SortingList[0] = "price asc" }),
+		"value missing":    modifyCursor(func(c *cursor) { c.ValueList = c.ValueList[:1] }),
+		"value type":       modifyCursor(func(c *cursor) { c.ValueList[0] = []byte(`"high"`) }),
+	} {
+		if _, err = dao.SelectByCursor(ctx, nil, models.NewQueryOption().SetPageSize(1).SetSorting(sorting).SetCursor(token)); !errors.Is(err, ErrInvalidQueryOption) {
+			t.Errorf("%s: err = %v, want ErrInvalidQueryOption", name, err)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package dao
+
+import (
+	"errors"
+	"fmt"
+	"goDict/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	"reflect"
+	"strings"
+)
+
+// ErrInvalidQueryOption 查询选项无效，如列不是模型中的字段、操作符或值不正确
+var ErrInvalidQueryOption = errors.New("invalid query option")
+
+// getSchema 模型的 schema，由 gorm 缓存
+func (d *BaseDao[T]) getSchema() (*schema.Schema, error) {
+	statement := &gorm.Statement{DB: d.db}
+	if err := statement.Parse(new(T)); nil != err {
+		return nil, err
+	}
+	return statement.Schema, nil
+}
+
+// applyFilter 添加过滤条件
+func (d *BaseDao[T]) applyFilter(db *gorm.DB, queryOptions *models.QueryOption) (*gorm.DB, error) {
+	if nil == queryOptions || nil == queryOptions.Filter {
+		return db, nil
+	}
+	modelSchema, err := d.getSchema()
+	if nil != err {
+		return nil, err
+	}
+	expression, err := buildFilterExpression(modelSchema, queryOptions.Filter)
+	if nil != err {
+		return nil, err
+	}
+	if nil == expression {
+		return db, nil
+	}
+	return db.Where(expression), nil
+}
+
+// applySorting 添加排序
+func (d *BaseDao[T]) applySorting(db *gorm.DB, queryOptions *models.QueryOption) (*gorm.DB, error) {
+	if nil == queryOptions || 0 == len(queryOptions.Sorting) {
+		return db, nil
+	}
+	modelSchema, err := d.getSchema()
+	if nil != err {
+		return nil, err
+	}
+	orderByColumnList, err := buildOrderByColumnList(modelSchema, queryOptions.Sorting)
+	if nil != err {
+		return nil, err
+	}
+	for _, orderByColumn := range orderByColumnList {
+		db = db.Order(orderByColumn)
+	}
+	return db, nil
+}
+
+// buildOrderByColumnList 解析排序，格式为 "列名 [asc|desc]"
+func buildOrderByColumnList(modelSchema *schema.Schema, sortingList []string) ([]clause.OrderByColumn, error) {
+	orderByColumnList := []clause.OrderByColumn{}
+	for _, sorting := range sortingList {
+		itemList := strings.Fields(sorting)
+		if 0 == len(itemList) || 2 < len(itemList) {
+			return nil, fmt.Errorf("%w: 无效的排序 %q", ErrInvalidQueryOption, sorting)
+		}
+		column, err := lookUpColumn(modelSchema, itemList[0])
+		if nil != err {
+			return nil, err
+		}
+		desc := false
+		if 2 == len(itemList) {
+			switch strings.ToLower(itemList[1]) {
+			case "asc":
+			case "desc":
+				desc = true
+			default:
+				return nil, fmt.Errorf("%w: 无效的排序 %q", ErrInvalidQueryOption, sorting)
+			}
+		}
+		orderByColumnList = append(orderByColumnList, clause.OrderByColumn{Column: column, Desc: desc})
+	}
+	return orderByColumnList, nil
+}
+
+// lookUpColumn 查找列，列名或字段名须为模型中的字段，防止注入
+func lookUpColumn(modelSchema *schema.Schema, name string) (clause.Column, error) {
+	field := modelSchema.LookUpField(name)
+	if nil == field || "" == field.DBName {
+		return clause.Column{}, fmt.Errorf("%w: 无效的列 %q", ErrInvalidQueryOption, name)
+	}
+	return clause.Column{Name: field.DBName}, nil
+}
+
+// buildFilterExpression 将过滤条件树转为查询条件，空条件及空的分组返回 nil
+func buildFilterExpression(modelSchema *schema.Schema, filter *models.Filter) (clause.Expression, error) {
+	// 空条件（如请求中的 "filter": {}）视为无条件
+	if nil == filter || (!filter.IsGroup() && "" == filter.Column && "" == filter.Operator && nil == filter.Value) {
+		return nil, nil
+	}
+
+	// 分组
+	if filter.IsGroup() {
+		if 0 < len(filter.And) && 0 < len(filter.Or) {
+			return nil, fmt.Errorf("%w: and 与 or 不能同时使用", ErrInvalidQueryOption)
+		}
+		expressionList := []clause.Expression{}
+		for _, child := range append(filter.And, filter.Or...) {
+			expression, err := buildFilterExpression(modelSchema, child)
+			if nil != err {
+				return nil, err
+			}
+			if nil != expression {
+				expressionList = append(expressionList, expression)
+			}
+		}
+		if 0 == len(expressionList) {
+			return nil, nil
+		}
+		if 0 < len(filter.Or) {
+			return clause.Or(expressionList...), nil
+		}
+		return clause.And(expressionList...), nil
+	}
+
+	// 条件
+	column, err := lookUpColumn(modelSchema, filter.Column)
+	if nil != err {
+		return nil, err
+	}
+	switch filter.Operator {
+	case models.FilterOperatorIsNull:
+		return clause.Eq{Column: column, Value: nil}, nil
+	case models.FilterOperatorIsNotNull:
+		return clause.Neq{Column: column, Value: nil}, nil
+	}
+	// 其他操作符必须有值，空值请使用 isNull、isNotNull
+	if nil == filter.Value {
+		return nil, fmt.Errorf("%w: %s 缺少值", ErrInvalidQueryOption, filter.Column)
+	}
+	switch filter.Operator {
+	case models.FilterOperatorEq:
+		return clause.Eq{Column: column, Value: filter.Value}, nil
+	case models.FilterOperatorNe:
+		return clause.Neq{Column: column, Value: filter.Value}, nil
+	case models.FilterOperatorGt:
+		return clause.Gt{Column: column, Value: filter.Value}, nil
+	case models.FilterOperatorGe:
+		return clause.Gte{Column: column, Value: filter.Value}, nil
+	case models.FilterOperatorLt:
+		return clause.Lt{Column: column, Value: filter.Value}, nil
+	case models.FilterOperatorLe:
+		return clause.Lte{Column: column, Value: filter.Value}, nil
+	case models.FilterOperatorLike:
+		return clause.Like{Column: column, Value: filter.Value}, nil
+	case models.FilterOperatorBetween:
+		valueList, ok := toValueList(filter.Value)
+		if !ok || 2 != len(valueList) {
+			return nil, fmt.Errorf("%w: %s between 需要两个值", ErrInvalidQueryOption, filter.Column)
+		}
+		return clause.Expr{SQL: "? BETWEEN ? AND ?", Vars: []interface{}{column, valueList[0], valueList[1]}}, nil
+	case models.FilterOperatorIn:
+		valueList, ok := toValueList(filter.Value)
+		if !ok || 0 == len(valueList) {
+			return nil, fmt.Errorf("%w: %s in 需要至少一个值", ErrInvalidQueryOption, filter.Column)
+		}
+		return clause.IN{Column: column, Values: valueList}, nil
+	}
+	return nil, fmt.Errorf("%w: 无效的操作符 %q", ErrInvalidQueryOption, filter.Operator)
+}
+
+// toValueList 切片转为 []interface{}
+func toValueList(value interface{}) ([]interface{}, bool) {
+	reflectValue := reflect.ValueOf(value)
+	if reflect.Slice != reflectValue.Kind() && reflect.Array != reflectValue.Kind() {
+		return nil, false
+	}
+	valueList := make([]interface{}, reflectValue.Len())
+	for i := range valueList {
+		valueList[i] = reflectValue.Index(i).Interface()
+	}
+	return valueList, true
+}
@@ -0,0 +1,260 @@
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"goDict/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// filterEntity 测试模型，note 可为空
+type filterEntity struct {
+	ID    uint `gorm:"primarykey"`
+	Name  string
+	Price int
+	Note  *string
+}
+
+func (filterEntity) TableName() string {
+	return "filter_entity"
+}
+
+func (e filterEntity) GetID() uint {
+	return e.ID
+}
+
+// openFilterTestDb 创建 filter_entity 表并插入测试数据
+func openFilterTestDb(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := openTestDb(t)
+	if err := db.AutoMigrate(&filterEntity{}); nil != err {
+		t.Fatal(err)
+	}
+	note := func(value string) *string { return &value }
+	entityList := []*filterEntity{
+		{Name: "apple", Price: 10, Note: note("red")},
+		{Name: "banana", Price: 20},
+		{Name: "cherry", Price: 30, Note: note("dark red")},
+		{Name: "date", Price: 40},
+		{Name: "elder", Price: 50, Note: note("x")},
+	}
+	if err := db.Create(entityList).Error; nil != err {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// selectIdList 按条件查询，返回按主键排序的 ID
+func selectIdList(t *testing.T, dao *BaseDao[filterEntity], queryOption *models.QueryOption) ([]uint, error) {
+	t.Helper()
+	queryResult, err := dao.SelectByQuery(context.Background(), nil, queryOption.SetPageSize(100))
+	if nil != err {
+		return nil, err
+	}
+	idList := []uint{}
+	for _, entity := range queryResult.Data {
+		idList = append(idList, entity.ID)
+	}
+	return idList, nil
+}
+
+func TestFilter(t *testing.T) {
+	dao := NewBaseDao[filterEntity](openFilterTestDb(t))
+
+	testCaseList := []struct {
+		name   string
+		filter *models.Filter
+		want   []uint
+	}{
+		{"eq", models.Eq("name", "apple"), []uint{1}},
+		{"ne", models.Ne("name", "apple"), []uint{2, 3, 4, 5}},
+		{"gt", &models.Filter{Column: "price", Operator: models.FilterOperatorGt, Value: 30}, []uint{4, 5}},
+		{"ge", &models.Filter{Column: "price", Operator: models.FilterOperatorGe, Value: 30}, []uint{3, 4, 5}},
+		{"lt", &models.Filter{Column: "price", Operator: models.FilterOperatorLt, Value: 30}, []uint{1, 2}},
+		{"le", &models.Filter{Column: "price", Operator: models.FilterOperatorLe, Value: 30}, []uint{1, 2, 3}},
+		{"between", &models.Filter{Column: "price", Operator: models.FilterOperatorBetween, Value: []int{20, 40}}, []uint{2, 3, 4}},
+		{"in", &models.Filter{Column: "name", Operator: models.FilterOperatorIn, Value: []string{"apple", "date"}}, []uint{1, 4}},
+		{"like", &models.Filter{Column: "name", Operator: models.FilterOperatorLike, Value: "%an%"}, []uint{2}},
+		{"isNull", &models.Filter{Column: "note", Operator: models.FilterOperatorIsNull}, []uint{2, 4}},
+		{"isNotNull", &models.Filter{Column: "note", Operator: models.FilterOperatorIsNotNull}, []uint{1, 3, 5}},
+		// 字段名与列名均可
+		{"field name", &models.Filter{Column: "Price", Operator: models.FilterOperatorGt, Value: 40}, []uint{5}},
+		{"and", &models.Filter{And: []*models.Filter{
+			{Column: "price", Operator: models.FilterOperatorGe, Value: 20},
+			{Column: "note", Operator: models.FilterOperatorIsNotNull},
+		}}, []uint{3, 5}},
+		{"or", &models.Filter{Or: []*models.Filter{
+			models.Eq("name", "apple"),
+			{Column: "price", Operator: models.FilterOperatorGt, Value: 40},
+		}}, []uint{1, 5}},
+		// price <= 40 AND (note IS NULL OR name LIKE 'c%')
+		{"nested", &models.Filter{And: []*models.Filter{
+			{Column: "price", Operator: models.FilterOperatorLe, Value: 40},
+			{Or: []*models.Filter{
+				{Column: "note", Operator: models.FilterOperatorIsNull},
+				{Column: "name", Operator: models.FilterOperatorLike, Value: "c%"},
+			}},
+		}}, []uint{2, 3, 4}},
+		{"empty", &models.Filter{}, []uint{1, 2, 3, 4, 5}},
+	}
+	for _, testCase := range testCaseList {
+		t.Run(testCase.name, func(t *testing.T) {
+			idList, err := selectIdList(t, dao, models.NewQueryOption().SetFilter(testCase.filter).SetSorting([]string{"id"}))
+			if nil != err {
+				t.Fatal(err)
+			}
+			if !slices.Equal(testCase.want, idList) {
+				t.Errorf("id list = %v, want %v", idList, testCase.want)
+			}
+		})
+	}
+
+	// 由请求参数解码的条件，值为 float64 及 []interface{}
+	filter := &models.Filter{}
+	if err := json.Unmarshal([]byte(`{"or":[{"column":"price","operator":"in","value":[10,50]},{"column":"name","operator":"eq","value":"date"}]}`), filter); nil != err {
+		t.Fatal(err)
+	}
+	idList, err := selectIdList(t, dao, models.NewQueryOption().SetFilter(filter).SetSorting([]string{"id"}))
+	if nil != err || !slices.Equal([]uint{1, 4, 5}, idList) {
+		t.Errorf("json filter: id list = %v, err = %v, want [1 4 5]", idList, err)
+	}
+}
+
+func TestSelectByQueryCount(t *testing.T) {
+	dao := NewBaseDao[filterEntity](openFilterTestDb(t))
+	ctx := context.Background()
+	filter := &models.Filter{Column: "price", Operator: models.FilterOperatorGe, Value: 30}
+
+	// 总数与数据使用相同的条件，不受分页影响
+	testCaseList := []struct {
+		query     *filterEntity
+		wantCount int64
+	}{
+		{nil, 3},
+		{&filterEntity{}, 3},
+		{&filterEntity{Name: "cherry"}, 1},
+	}
+	for _, testCase := range testCaseList {
+		queryResult, err := dao.SelectByQuery(ctx, testCase.query, models.NewQueryOption().SetPageSize(1).SetPageNum(2).SetFilter(filter))
+		if nil != err {
+			t.Fatal(err)
+		}
+		if nil == queryResult.TotalCount || testCase.wantCount != *queryResult.TotalCount || nil == queryResult.PageCount || int(testCase.wantCount) != *queryResult.PageCount {
+			t.Errorf("query %+v: total count = %v, page count = %v, want %d", testCase.query, queryResult.TotalCount, queryResult.PageCount, testCase.wantCount)
+		}
+	}
+
+	queryResult, err := dao.SelectByIdList(ctx, []uint{1, 3, 5}, models.NewQueryOption().SetPageSize(1).SetFilter(filter))
+	if nil != err {
+		t.Fatal(err)
+	}
+	if nil == queryResult.TotalCount || 2 != *queryResult.TotalCount || 1 != len(queryResult.Data) {
+		t.Errorf("id list: total count = %v, data = %d, want 2 and 1", queryResult.TotalCount, len(queryResult.Data))
+	}
+
+	// 不统计总数时为 nil，与统计结果为 0 区分
+	queryResult, err = dao.SelectByQuery(ctx, nil, models.NewQueryOption().SetFilter(filter).SetSkipCount(true))
+	if nil != err || nil != queryResult.TotalCount || nil != queryResult.PageCount || 3 != len(queryResult.Data) {
+		t.Errorf("skip count: total count = %v, page count = %v, err = %v, want nil", queryResult.TotalCount, queryResult.PageCount, err)
+	}
+	queryResult, err = dao.SelectByIdList(ctx, []uint{1, 3, 5}, models.NewQueryOption().SetSkipCount(true))
+	if nil != err || nil != queryResult.TotalCount {
+		t.Errorf("id list skip count: total count = %v, err = %v, want nil", queryResult.TotalCount, err)
+	}
+	queryResult, err = dao.SelectByQuery(ctx, &filterEntity{Name: "none"}, models.NewQueryOption())
+	if nil != err || nil == queryResult.TotalCount || 0 != *queryResult.TotalCount || nil == queryResult.PageCount || 0 != *queryResult.PageCount {
+		t.Errorf("empty: total count = %v, page count = %v, err = %v, want 0", queryResult.TotalCount, queryResult.PageCount, err)
+	}
+}
+
+func TestSorting(t *testing.T) {
+	dao := NewBaseDao[filterEntity](openFilterTestDb(t))
+
+	testCaseList := []struct {
+		sorting []string
+		want    []uint
+	}{
+		{[]string{"price desc"}, []uint{5, 4, 3, 2, 1}},
+		{[]string{"Name DESC"}, []uint{5, 4, 3, 2, 1}},
+		{[]string{"note desc", "id asc"}, []uint{5, 1, 3, 2, 4}},
+		{[]string{"id"}, []uint{1, 2, 3, 4, 5}},
+	}
+	for _, testCase := range testCaseList {
+		idList, err := selectIdList(t, dao, models.NewQueryOption().SetSorting(testCase.sorting))
+		if nil != err {
+			t.Fatal(err)
+		}
+		if !slices.Equal(testCase.want, idList) {
+			t.Errorf("sorting %q: id list = %v, want %v", testCase.sorting, idList, testCase.want)
+		}
+	}
+}
+
+func TestInvalidQueryOption(t *testing.T) {
+	db := openFilterTestDb(t)
+	dao := NewBaseDao[filterEntity](db)
+
+	// 列须为模型中的字段，注入的内容不会进入 SQL
+	injectedList := []string{"unknown", "name; drop table filter_entity", "id desc, (select 1)", "1=1 or name", "filter_entity.name", ""}
+	type testCase struct {
+		name        string
+		queryOption *models.QueryOption
+	}
+	testCaseList := []testCase{
+		{"and with or", models.NewQueryOption().SetFilter(&models.Filter{And: []*models.Filter{models.Eq("id", 1)}, Or: []*models.Filter{models.Eq("id", 2)}})},
+		{"unknown operator", models.NewQueryOption().SetFilter(&models.Filter{Column: "name", Operator: "regexp", Value: "a"})},
+		{"missing value", models.NewQueryOption().SetFilter(&models.Filter{Column: "name", Operator: models.FilterOperatorEq})},
+		{"between one value", models.NewQueryOption().SetFilter(&models.Filter{Column: "price", Operator: models.FilterOperatorBetween, Value: []int{1}})},
+		{"between not slice", models.NewQueryOption().SetFilter(&models.Filter{Column: "price", Operator: models.FilterOperatorBetween, Value: 1})},
+		{"empty in", models.NewQueryOption().SetFilter(&models.Filter{Column: "price", Operator: models.FilterOperatorIn, Value: []int{}})},
+		{"nested invalid column", models.NewQueryOption().SetFilter(&models.Filter{Or: []*models.Filter{models.Eq("id", 1), models.Eq("name; drop table filter_entity", 1)}})},
+		{"sorting direction", models.NewQueryOption().SetSorting([]string{"price sideways"})},
+		{"sorting too many items", models.NewQueryOption().SetSorting([]string{"price desc nulls"})},
+	}
+	for _, column := range injectedList {
+		testCaseList = append(testCaseList,
+			testCase{"filter " + column, models.NewQueryOption().SetFilter(models.Eq(column, 1))},
+			testCase{"sorting " + column, models.NewQueryOption().SetSorting([]string{column})},
+		)
+	}
+	for _, item := range testCaseList {
+		if _, err := selectIdList(t, dao, item.queryOption); !errors.Is(err, ErrInvalidQueryOption) {
+			t.Errorf("%s: err = %v, want ErrInvalidQueryOption", item.name, err)
+		}
+		if _, err := dao.SelectByCursor(context.Background(), nil, item.queryOption); !errors.Is(err, ErrInvalidQueryOption) {
+			t.Errorf("%s: cursor err = %v, want ErrInvalidQueryOption", item.name, err)
+		}
+	}
+
+	var count int64
+	if err := db.Model(&filterEntity{}).Count(&count).Error; nil != err || 5 != count {
+		t.Errorf("count = %d, err = %v, want 5", count, err)
+	}
+}
+
+func TestBuildFilterExpressionEmpty(t *testing.T) {
+	modelSchema, err := schema.Parse(&batchEntity{}, &sync.Map{}, schema.NamingStrategy{})
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	// 空条件、空分组及只含空条件的分组均视为无条件
+	for _, filter := range []*models.Filter{nil, {}, {And: []*models.Filter{{}}}, {Or: []*models.Filter{{}, {}}}} {
+		expression, err := buildFilterExpression(modelSchema, filter)
+		if nil != err || nil != expression {
+			t.Errorf("filter %+v: expression = %v, err = %v, want nil", filter, expression, err)
+		}
+	}
+
+	// 缺少列的条件仍然无效
+	for _, filter := range []*models.Filter{{Operator: models.FilterOperatorEq, Value: 1}, {Value: 1}} {
+		if _, err := buildFilterExpression(modelSchema, filter); !errors.Is(err, ErrInvalidQueryOption) {
+			t.Errorf("filter %+v: err = %v, want ErrInvalidQueryOption", filter, err)
+		}
+	}
+}
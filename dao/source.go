@@ -0,0 +1,8 @@
+package dao
+
+import "embed"
+
+// SourceFS 生成代码时输出到目标项目 DAO 目录的源码：BaseDao 及其过滤、游标分页、审计、批量操作、事务
+//
+//go:embed base_dao.go entity_audit.go entity_batch.go query_cursor.go query_filter.go transaction.go
+var SourceFS embed.FS
@@ -0,0 +1,91 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+)
+
+// ErrNoTransaction 上下文中没有由 BeginTransaction 开启的事务
+var ErrNoTransaction = errors.New("no transaction in context")
+
+// txContextKey 上下文中事务的键
+type txContextKey struct{}
+
+// transaction 上下文中的事务
+type transaction struct {
+	// 事务
+	tx *gorm.DB
+	// 保存点名称，最外层为空
+	savePoint string
+	// 已创建的保存点数，同一事务中共享，保存点依次命名为 sp1、sp2……，同层的嵌套事务不会重名
+	savePointCount *int
+	// 由 WithTransaction 提交或回滚
+	managed bool
+}
+
+// contextWithTx 将事务放入上下文，嵌套时与外层事务共享保存点计数
+func contextWithTx(ctx context.Context, tx *gorm.DB, savePoint string, managed bool) context.Context {
+	savePointCount := new(int)
+	if parent, ok := ctx.Value(txContextKey{}).(*transaction); ok {
+		savePointCount = parent.savePointCount
+	}
+	return context.WithValue(ctx, txContextKey{}, &transaction{tx: tx, savePoint: savePoint, savePointCount: savePointCount, managed: managed})
+}
+
+// GetDbFromContext 从上下文中获取数据库实例（支持事务）
+func GetDbFromContext(ctx context.Context, defaultDb *gorm.DB) *gorm.DB {
+	if current, ok := ctx.Value(txContextKey{}).(*transaction); ok {
+		return current.tx
+	}
+	return defaultDb
+}
+
+// getDb 当前上下文使用的数据库实例，上下文中有事务时使用事务
+func (d *BaseDao[T]) getDb(ctx context.Context) *gorm.DB {
+	return GetDbFromContext(ctx, d.db).WithContext(ctx)
+}
+
+// BeginTransaction 开启事务，返回带有事务的上下文，之后使用该上下文的 DAO 都在此事务中执行；
+// 上下文中已有事务时创建保存点，Commit 时保留保存点中的修改，Rollback 时回滚到保存点
+func BeginTransaction(ctx context.Context, db *gorm.DB) (context.Context, error) {
+	if current, ok := ctx.Value(txContextKey{}).(*transaction); ok {
+		*current.savePointCount++
+		savePoint := fmt.Sprintf("sp%d", *current.savePointCount)
+		if err := current.tx.SavePoint(savePoint).Error; nil != err {
+			return ctx, err
+		}
+		return contextWithTx(ctx, current.tx, savePoint, false), nil
+	}
+	tx := db.WithContext(ctx).Begin()
+	if nil != tx.Error {
+		return ctx, tx.Error
+	}
+	return contextWithTx(ctx, tx, "", false), nil
+}
+
+// CommitTransaction 提交 BeginTransaction 开启的事务；
+// 嵌套事务（保存点）不执行 RELEASE SAVEPOINT（SQL Server、Oracle 不支持），保存点保留到外层事务结束，其中的修改随外层事务提交或回滚
+func CommitTransaction(ctx context.Context) error {
+	current, ok := ctx.Value(txContextKey{}).(*transaction)
+	if !ok || current.managed {
+		return ErrNoTransaction
+	}
+	if "" != current.savePoint {
+		return nil
+	}
+	return current.tx.Commit().Error
+}
+
+// RollbackTransaction 回滚 BeginTransaction 开启的事务，保存点回滚到创建时的状态
+func RollbackTransaction(ctx context.Context) error {
+	current, ok := ctx.Value(txContextKey{}).(*transaction)
+	if !ok || current.managed {
+		return ErrNoTransaction
+	}
+	if "" != current.savePoint {
+		return current.tx.RollbackTo(current.savePoint).Error
+	}
+	return current.tx.Rollback().Error
+}
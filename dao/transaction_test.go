@@ -0,0 +1,215 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"slices"
+	"testing"
+)
+
+// selectCodeList 查询 batch_entity 的 code，有事务时在事务中查询
+func selectCodeList(t *testing.T, ctx context.Context, db *gorm.DB) []string {
+	t.Helper()
+	var codeList []string
+	if err := GetDbFromContext(ctx, db).Model(&batchEntity{}).Order("code").Pluck("code", &codeList).Error; nil != err {
+		t.Fatal(err)
+	}
+	return codeList
+}
+
+// insertCode 在上下文的事务中插入一行
+func insertCode(t *testing.T, ctx context.Context, dao *BaseDao[batchEntity], code string) {
+	t.Helper()
+	if err := dao.Insert(ctx, &batchEntity{Code: code}); nil != err {
+		t.Fatal(err)
+	}
+}
+
+func TestBeginTransaction(t *testing.T) {
+	db := openTestDb(t)
+	dao := NewBaseDao[batchEntity](db)
+
+	// 提交后可见
+	txCtx, err := BeginTransaction(context.Background(), db)
+	if nil != err {
+		t.Fatal(err)
+	}
+	insertCode(t, txCtx, dao, "a")
+	if err = CommitTransaction(txCtx); nil != err {
+		t.Fatal(err)
+	}
+
+	// 回滚后不可见
+	txCtx, err = BeginTransaction(context.Background(), db)
+	if nil != err {
+		t.Fatal(err)
+	}
+	insertCode(t, txCtx, dao, "b")
+	if codeList := selectCodeList(t, txCtx, db); !slices.Equal([]string{"a", "b"}, codeList) {
+		t.Errorf("in transaction = %v, want [a b]", codeList)
+	}
+	if err = RollbackTransaction(txCtx); nil != err {
+		t.Fatal(err)
+	}
+
+	if codeList := selectCodeList(t, context.Background(), db); !slices.Equal([]string{"a"}, codeList) {
+		t.Errorf("after commit and rollback = %v, want [a]", codeList)
+	}
+}
+
+func TestNestedTransaction(t *testing.T) {
+	db := openTestDb(t)
+	dao := NewBaseDao[batchEntity](db)
+	ctx := context.Background()
+
+	txCtx, err := BeginTransaction(ctx, db)
+	if nil != err {
+		t.Fatal(err)
+	}
+	insertCode(t, txCtx, dao, "a")
+
+	// 回滚保存点，保留外层的修改
+	innerCtx, err := BeginTransaction(txCtx, db)
+	if nil != err {
+		t.Fatal(err)
+	}
+	insertCode(t, innerCtx, dao, "b")
+	if err = RollbackTransaction(innerCtx); nil != err {
+		t.Fatal(err)
+	}
+
+	// 同层的嵌套事务使用不同的保存点
+	siblingCtx, err := BeginTransaction(txCtx, db)
+	if nil != err {
+		t.Fatal(err)
+	}
+	savePoint := innerCtx.Value(txContextKey{}).(*transaction).savePoint
+	if siblingSavePoint := siblingCtx.Value(txContextKey{}).(*transaction).savePoint; savePoint == siblingSavePoint {
+		t.Errorf("sibling save point = %s, want different from %s", siblingSavePoint, savePoint)
+	}
+	insertCode(t, siblingCtx, dao, "c")
+
+	// 再嵌套一层后回滚，只回滚到该层的保存点
+	deepCtx, err := BeginTransaction(siblingCtx, db)
+	if nil != err {
+		t.Fatal(err)
+	}
+	insertCode(t, deepCtx, dao, "d")
+	if err = RollbackTransaction(deepCtx); nil != err {
+		t.Fatal(err)
+	}
+	if err = CommitTransaction(siblingCtx); nil != err {
+		t.Fatal(err)
+	}
+	if codeList := selectCodeList(t, txCtx, db); !slices.Equal([]string{"a", "c"}, codeList) {
+		t.Errorf("before outer commit = %v, want [a c]", codeList)
+	}
+	if err = CommitTransaction(txCtx); nil != err {
+		t.Fatal(err)
+	}
+	if codeList := selectCodeList(t, ctx, db); !slices.Equal([]string{"a", "c"}, codeList) {
+		t.Errorf("after outer commit = %v, want [a c]", codeList)
+	}
+
+	// 外层回滚时，已提交的嵌套事务一并回滚
+	txCtx, err = BeginTransaction(ctx, db)
+	if nil != err {
+		t.Fatal(err)
+	}
+	innerCtx, err = BeginTransaction(txCtx, db)
+	if nil != err {
+		t.Fatal(err)
+	}
+	insertCode(t, innerCtx, dao, "e")
+	if err = CommitTransaction(innerCtx); nil != err {
+		t.Fatal(err)
+	}
+	if err = RollbackTransaction(txCtx); nil != err {
+		t.Fatal(err)
+	}
+	if codeList := selectCodeList(t, ctx, db); !slices.Equal([]string{"a", "c"}, codeList) {
+		t.Errorf("after outer rollback = %v, want [a c]", codeList)
+	}
+}
+
+func TestSharedTransaction(t *testing.T) {
+	db := openTestDb(t)
+	entityDao := NewBaseDao[batchEntity](db)
+	tagDao := NewBaseDao[batchTag](db)
+	ctx := context.Background()
+
+	// 两个 DAO 共用上下文中的事务，一起回滚
+	txCtx, err := BeginTransaction(ctx, db)
+	if nil != err {
+		t.Fatal(err)
+	}
+	insertCode(t, txCtx, entityDao, "a")
+	if err = tagDao.Insert(txCtx, &batchTag{Code: "x"}); nil != err {
+		t.Fatal(err)
+	}
+	if err = RollbackTransaction(txCtx); nil != err {
+		t.Fatal(err)
+	}
+	var entityCount, tagCount int64
+	db.Model(&batchEntity{}).Count(&entityCount)
+	db.Model(&batchTag{}).Count(&tagCount)
+	if 0 != entityCount || 0 != tagCount {
+		t.Errorf("after rollback: entity count = %d, tag count = %d, want 0", entityCount, tagCount)
+	}
+
+	// WithTransaction 中 DAO 使用同一事务，返回错误时一起回滚
+	errTest := errors.New("test")
+	err = entityDao.WithTransaction(ctx, func(txCtx context.Context) error {
+		insertCode(t, txCtx, entityDao, "b")
+		if err := tagDao.Insert(txCtx, &batchTag{Code: "y"}); nil != err {
+			return err
+		}
+		return errTest
+	})
+	if !errors.Is(err, errTest) {
+		t.Fatalf("err = %v, want %v", err, errTest)
+	}
+	db.Model(&batchEntity{}).Count(&entityCount)
+	db.Model(&batchTag{}).Count(&tagCount)
+	if 0 != entityCount || 0 != tagCount {
+		t.Errorf("after WithTransaction error: entity count = %d, tag count = %d, want 0", entityCount, tagCount)
+	}
+}
+
+func TestErrNoTransaction(t *testing.T) {
+	db := openTestDb(t)
+	dao := NewBaseDao[batchEntity](db)
+
+	// 上下文中没有事务
+	if err := CommitTransaction(context.Background()); !errors.Is(err, ErrNoTransaction) {
+		t.Errorf("commit err = %v, want ErrNoTransaction", err)
+	}
+	if err := RollbackTransaction(context.Background()); !errors.Is(err, ErrNoTransaction) {
+		t.Errorf("rollback err = %v, want ErrNoTransaction", err)
+	}
+
+	// WithTransaction 的事务由其提交或回滚，可在其中嵌套 BeginTransaction
+	err := dao.WithTransaction(context.Background(), func(txCtx context.Context) error {
+		if err := CommitTransaction(txCtx); !errors.Is(err, ErrNoTransaction) {
+			t.Errorf("managed commit err = %v, want ErrNoTransaction", err)
+		}
+		if err := RollbackTransaction(txCtx); !errors.Is(err, ErrNoTransaction) {
+			t.Errorf("managed rollback err = %v, want ErrNoTransaction", err)
+		}
+		insertCode(t, txCtx, dao, "a")
+
+		innerCtx, err := BeginTransaction(txCtx, db)
+		if nil != err {
+			return err
+		}
+		insertCode(t, innerCtx, dao, "b")
+		return RollbackTransaction(innerCtx)
+	})
+	if nil != err {
+		t.Fatal(err)
+	}
+	if codeList := selectCodeList(t, context.Background(), db); !slices.Equal([]string{"a"}, codeList) {
+		t.Errorf("after WithTransaction = %v, want [a]", codeList)
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"goDict/configs"
 	"goDict/services"
@@ -19,13 +20,22 @@ func generateDb(dbConfig *configs.DatabaseConfig, saveDirPath string, format str
 		slog.Error("数据库连接失败", "error", err)
 		return "", err
 	}
+	// 用完后关闭连接及 SSH 隧道
+	defer configs.CloseDatabase(db)
 
 	// 是否启用数据库模型生产
 	if DB_GEN {
 		// 使用GeneratorService生成模型
 		generatorService := services.NewGeneratorService(db)
-		// 生成实体类
-		generatorService.GenerateModels("./dao/generate", "./models", "./services")
+		// 生成实体类，只生成模型及服务，不生成处理器、TypeScript、Java、proto
+		option := services.NewGenerateOption(".", "goDict")
+		option.HandlerPackage = ""
+		option.TypeScriptPackage = ""
+		option.JavaPackage = ""
+		option.ProtoPackage = ""
+		if _, err := generatorService.GenerateModels(context.Background(), dbConfig, option, nil); nil != err {
+			return "", err
+		}
 	}
 
 	/*
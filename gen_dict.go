@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"goDict/configs"
 	"goDict/models"
@@ -9,34 +10,38 @@ import (
 )
 
 // getDatabaseInfo 获取数据库信息
-func getDatabaseInfo(dbConfig *configs.DatabaseConfig, selectedTableNameList []string) (*models.DatabaseInfo, error) {
+func getDatabaseInfo(ctx context.Context, dbConfig *configs.DatabaseConfig, selectedTableNameList []string, progress services.ProgressFunc) (*models.DatabaseInfo, error) {
 	// 初始化数据库连接
 	db, err := configs.InitDatabase(dbConfig)
 	if err != nil {
 		slog.Error("数据库连接失败", "error", err)
 		return nil, err
 	}
+	// 用完后关闭连接及 SSH 隧道
+	defer configs.CloseDatabase(db)
 
 	// 生成数据库字典服务
 	dbDictService := services.NewDbDictService(db)
 
-	return dbDictService.GetDatabaseInfo(dbConfig, selectedTableNameList)
+	return dbDictService.GetDatabaseInfo(ctx, dbConfig, selectedTableNameList, progress)
 }
 
-// generateDict 生成字典
-func generateDict(dbConfig *configs.DatabaseConfig, saveDirPath string, format string, selectedTableNameList []string) (string, error) {
+// generateDict 生成字典，ctx 取消时删除已生成的文件
+func generateDict(ctx context.Context, dbConfig *configs.DatabaseConfig, saveDirPath string, format string, selectedTableNameList []string, progress services.ProgressFunc) (string, error) {
 	// 初始化数据库连接
 	db, err := configs.InitDatabase(dbConfig)
 	if err != nil {
 		slog.Error("数据库连接失败", "error", err)
 		return "", err
 	}
+	// 用完后关闭连接及 SSH 隧道
+	defer configs.CloseDatabase(db)
 
 	// 生成数据库字典服务
 	dbDictService := services.NewDbDictService(db)
 
 	// 生成数据库字典
-	pathList, err := dbDictService.BuildAll(dbConfig, saveDirPath, format, true, selectedTableNameList)
+	pathList, err := dbDictService.BuildAll(ctx, dbConfig, saveDirPath, format, true, selectedTableNameList, progress)
 	if err != nil {
 		slog.Error("生成失败", "error", err)
 		return "", err
@@ -49,22 +54,25 @@ func generateDict(dbConfig *configs.DatabaseConfig, saveDirPath string, format s
 	return string(bytes), err
 }
 
-// generateModel 生成模型
-func generateModel(dbConfig *configs.DatabaseConfig) (string, error) {
+// generateCode 生成模型及按模板生成的代码，返回按模板生成的文件列表；ctx 取消时停止生成
+func generateCode(ctx context.Context, dbConfig *configs.DatabaseConfig, option *services.GenerateOption, progress services.ProgressFunc) ([]string, error) {
 	// 初始化数据库连接
 	db, err := configs.InitDatabase(dbConfig)
 	if err != nil {
 		slog.Error("数据库连接失败", "error", err)
-		return "", err
+		return nil, err
 	}
+	// 用完后关闭连接及 SSH 隧道
+	defer configs.CloseDatabase(db)
 
-	// 是否启用数据库模型生产
-	if DB_GEN {
-		// 使用GeneratorService生成模型
-		generatorService := services.NewGeneratorService(db)
-		// 生成实体类
-		generatorService.GenerateModels("./dao/generate", "./models", "./services")
+	// 使用GeneratorService生成模型
+	generatorService := services.NewGeneratorService(db)
+	pathList, err := generatorService.GenerateModels(ctx, dbConfig, option, progress)
+	if err != nil {
+		slog.Error("生成代码失败", "error", err)
+		return pathList, err
 	}
+	slog.Info("生成代码成功", "pathList", pathList)
 
-	return "", nil
+	return pathList, nil
 }
@@ -4,6 +4,7 @@ import (
 	"fyne.io/fyne/v2/app"
 	"goDict/utils"
 	"log/slog"
+	"os"
 )
 
 var (
@@ -21,10 +22,15 @@ func main() {
 		slog.Error("Failed to initializing i18n.", "error", err)
 		panic(err)
 	}
+	// 命令行子命令
+	if 1 < len(os.Args) && cliCommandGenerateCode == os.Args[1] {
+		os.Exit(runGenerateCodeCommand(os.Args[2:]))
+	}
+
 	slog.Info("App is now running ...")
 
 	// 初始化GUI
-	appInstance := app.New()
+	appInstance := app.NewWithID(APP_ID)
 	mainView := NewMainView(&appInstance)
 	mainView.Show()
 
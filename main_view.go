@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"fyne.io/fyne/v2"
@@ -10,6 +11,7 @@ import (
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 	"goDict/configs"
+	"goDict/models"
 	"goDict/utils"
 	"golang.org/x/text/language"
 	"log/slog"
@@ -21,7 +23,7 @@ import (
 
 var (
 	// 数据库类型列表
-	DbTypeList = []string{"MySQL", "SQLServer", "Oracle", "SQLite", "PostgresSQL"}
+	DbTypeList = []string{"MySQL", "SQLServer", "Oracle", "SQLite", "PostgresSQL", "DuckDB", "MariaDB", "TiDB", "ClickHouse"}
 	// 数据库端口
 	DbPortMap = map[string]int{
 		"MySQL":       3306,
@@ -29,6 +31,10 @@ var (
 		"PostgresSQL": 5432,
 		"Oracle":      1521,
 		"SQLite":      0,
+		"DuckDB":      0,
+		"MariaDB":     3306,
+		"TiDB":        4000,
+		"ClickHouse":  9000,
 	}
 	// 支付编码列表
 	CharsetList = []string{"utf8mb4", "utf8", "gbk", "gb2312", "latin1"}
@@ -87,6 +93,46 @@ var (
 			"SelCharset":  false,
 			"TxtService":  false,
 		},
+		// DuckDB
+		"DuckDB": map[string]bool{
+			"TxtHost":     true,
+			"TxtPort":     false,
+			"TxtUsername": false,
+			"TxtPassword": false,
+			"TxtDbName":   false,
+			"SelCharset":  false,
+			"TxtService":  false,
+		},
+		// MariaDB
+		"MariaDB": map[string]bool{
+			"TxtHost":     true,
+			"TxtPort":     true,
+			"TxtUsername": true,
+			"TxtPassword": true,
+			"TxtDbName":   true,
+			"SelCharset":  true,
+			"TxtService":  false,
+		},
+		// TiDB
+		"TiDB": map[string]bool{
+			"TxtHost":     true,
+			"TxtPort":     true,
+			"TxtUsername": true,
+			"TxtPassword": true,
+			"TxtDbName":   true,
+			"SelCharset":  true,
+			"TxtService":  false,
+		},
+		// ClickHouse
+		"ClickHouse": map[string]bool{
+			"TxtHost":     true,
+			"TxtPort":     true,
+			"TxtUsername": true,
+			"TxtPassword": true,
+			"TxtDbName":   true,
+			"SelCharset":  false,
+			"TxtService":  false,
+		},
 	}
 )
 
@@ -102,8 +148,21 @@ type MainView struct {
 	LblTitle *widget.Label
 	// 基础表单
 	FormBasic *widget.Form
+	// 高级选项（TLS/SSL）
+	AccAdvanced  *widget.Accordion
+	FormAdvanced *widget.Form
+	// SSH 隧道
+	FormSsh *widget.Form
 
 	/* 控件 */
+	// 连接历史及收藏
+	SelHistory       *widget.Select
+	BtnFavourite     *widget.Button
+	BtnRemoveHistory *widget.Button
+	history          *ConnectionHistory
+	// 连接串模式
+	ChkRawDsn *widget.Check
+	TxtDsn    *widget.Entry
 	// 连接控件
 	SelDbType *widget.Select
 	TxtHost   *widget.Entry
@@ -115,17 +174,44 @@ type MainView struct {
 	TxtDbName   *widget.Entry
 	SelCharset  *widget.Select
 
+	// TLS/SSL 控件
+	SelSslMode           *widget.Select
+	TxtSslRootCert       *widget.Entry
+	BtnChooseSslRootCert *widget.Button
+	TxtSslCert           *widget.Entry
+	BtnChooseSslCert     *widget.Button
+	TxtSslKey            *widget.Entry
+	BtnChooseSslKey      *widget.Button
+	TxtSslServerName     *widget.Entry
+
+	// SSH 隧道控件
+	TxtSshHost             *widget.Entry
+	TxtSshPort             *widget.Entry
+	TxtSshUser             *widget.Entry
+	TxtSshPassword         *widget.Entry
+	TxtSshKeyFile          *widget.Entry
+	BtnChooseSshKeyFile    *widget.Button
+	TxtSshKeyPassphrase    *widget.Entry
+	TxtSshKnownHosts       *widget.Entry
+	BtnChooseSshKnownHosts *widget.Button
+
 	// 输出控件
 	TxtOutputDir       *widget.Entry
 	BtnChooseOutputDir *widget.Button
 	SelOutputFormat    *widget.Select
 
+	// 记住密码（保存到加密凭据库）
+	ChkRememberPassword *widget.Check
+
 	// 语言选择控件
 	SelLocale *widget.Select
+	// 凭据库解锁/锁定按钮
+	BtnCredentialStore *widget.Button
 	// 按钮
 	BtnTest              *widget.Button
 	BtnGenerate          *widget.Button
 	BtnCustomizeGenerate *widget.Button
+	BtnGenerateCode      *widget.Button
 
 	// 容器
 	container *fyne.Container
@@ -162,6 +248,21 @@ func (this *MainView) init(appInstance *fyne.App) {
 	this.BtnCustomizeGenerate = widget.NewButtonWithIcon(I("main-view.ui.BtnCustomizeGenerate.label"), theme.MediaSkipNextIcon(), this.btnCustomizeGenerate_onClicked)
 	this.BtnGenerate = widget.NewButtonWithIcon(I("main-view.ui.BtnGenerate.label"), theme.MediaPlayIcon(), this.btnGenerate_onClicked)
 	this.BtnGenerate.Importance = widget.HighImportance
+	this.BtnGenerateCode = widget.NewButtonWithIcon(I("main-view.ui.BtnGenerateCode.label"), theme.DocumentCreateIcon(), this.btnGenerateCode_onClicked)
+
+	/* 连接历史 */
+	this.history = NewConnectionHistory((*this.App).Preferences())
+	this.SelHistory = widget.NewSelect(nil, this.selHistory_onChanged)
+	this.SelHistory.PlaceHolder = I("main-view.ui.SelHistory.placeholder")
+	this.BtnFavourite = widget.NewButtonWithIcon("", theme.ConfirmIcon(), this.btnFavourite_onClicked)
+	this.BtnRemoveHistory = widget.NewButtonWithIcon("", theme.DeleteIcon(), this.btnRemoveHistory_onClicked)
+	this.refreshHistory(nil)
+
+	/* 连接串 */
+	this.TxtDsn = widget.NewEntry()
+	this.TxtDsn.SetPlaceHolder(I("main-view.ui.TxtDsn.placeholder"))
+	this.TxtDsn.Disable()
+	this.ChkRawDsn = widget.NewCheck(I("main-view.ui.ChkRawDsn.label"), this.chkRawDsn_onChanged)
 
 	/* 表单控件 */
 	// 数据库类型
@@ -182,6 +283,7 @@ func (this *MainView) init(appInstance *fyne.App) {
 	// 密码
 	this.TxtPassword = widget.NewPasswordEntry()
 	this.TxtPassword.SetPlaceHolder(I("main-view.ui.TxtPassword.placeholder"))
+	this.ChkRememberPassword = widget.NewCheck(I("main-view.ui.ChkRememberPassword.label"), this.chkRememberPassword_onChanged)
 	// 数据库名
 	this.TxtDbName = widget.NewEntry()
 	this.TxtDbName.SetPlaceHolder(I("main-view.ui.TxtDbName.placeholder"))
@@ -207,24 +309,95 @@ func (this *MainView) init(appInstance *fyne.App) {
 		widget.NewFormItem(I("main-view.ui.form.formItem.TxtPort.text"), this.TxtPort),
 		widget.NewFormItem(I("main-view.ui.form.formItem.TxtService.text"), this.TxtService),
 		widget.NewFormItem(I("main-view.ui.form.formItem.TxtUsername.text"), this.TxtUsername),
-		widget.NewFormItem(I("main-view.ui.form.formItem.TxtPassword.text"), this.TxtPassword),
+		widget.NewFormItem(I("main-view.ui.form.formItem.TxtPassword.text"), container.NewBorder(nil, nil, nil, this.ChkRememberPassword, this.TxtPassword)),
 		widget.NewFormItem(I("main-view.ui.form.formItem.TxtDbName.text"), this.TxtDbName),
 		widget.NewFormItem(I("main-view.ui.form.formItem.SelCharset.text"), this.SelCharset),
 		widget.NewFormItem(I("main-view.ui.form.formItem.SelOutputFormat.text"), this.SelOutputFormat),
 		widget.NewFormItem(I("main-view.ui.form.formItem.outputDirContainer.text"), outputDirContainer),
 	}}
 
+	/* 高级选项 */
+	// TLS/SSL 模式，未选择时沿用驱动默认行为
+	this.SelSslMode = widget.NewSelect(configs.SSLModeList, nil)
+	this.SelSslMode.PlaceHolder = I("main-view.ui.SelSslMode.placeholder")
+	// CA 证书
+	this.TxtSslRootCert = widget.NewEntry()
+	this.TxtSslRootCert.SetPlaceHolder(I("main-view.ui.TxtSslRootCert.placeholder"))
+	this.BtnChooseSslRootCert = widget.NewButton(I("main-view.ui.BtnChooseOutputDir.placeholder"), func() { this.chooseFile(this.TxtSslRootCert) })
+	// 客户端证书
+	this.TxtSslCert = widget.NewEntry()
+	this.TxtSslCert.SetPlaceHolder(I("main-view.ui.TxtSslCert.placeholder"))
+	this.BtnChooseSslCert = widget.NewButton(I("main-view.ui.BtnChooseOutputDir.placeholder"), func() { this.chooseFile(this.TxtSslCert) })
+	// 客户端私钥
+	this.TxtSslKey = widget.NewEntry()
+	this.TxtSslKey.SetPlaceHolder(I("main-view.ui.TxtSslKey.placeholder"))
+	this.BtnChooseSslKey = widget.NewButton(I("main-view.ui.BtnChooseOutputDir.placeholder"), func() { this.chooseFile(this.TxtSslKey) })
+	// 服务器名
+	this.TxtSslServerName = widget.NewEntry()
+	this.TxtSslServerName.SetPlaceHolder(I("main-view.ui.TxtSslServerName.placeholder"))
+
+	this.FormAdvanced = &widget.Form{Items: []*widget.FormItem{
+		widget.NewFormItem(I("main-view.ui.form.formItem.SelSslMode.text"), this.SelSslMode),
+		widget.NewFormItem(I("main-view.ui.form.formItem.TxtSslRootCert.text"), container.NewBorder(nil, nil, nil, this.BtnChooseSslRootCert, this.TxtSslRootCert)),
+		widget.NewFormItem(I("main-view.ui.form.formItem.TxtSslCert.text"), container.NewBorder(nil, nil, nil, this.BtnChooseSslCert, this.TxtSslCert)),
+		widget.NewFormItem(I("main-view.ui.form.formItem.TxtSslKey.text"), container.NewBorder(nil, nil, nil, this.BtnChooseSslKey, this.TxtSslKey)),
+		widget.NewFormItem(I("main-view.ui.form.formItem.TxtSslServerName.text"), this.TxtSslServerName),
+	}}
+
+	/* SSH 隧道 */
+	// 跳板机地址，为空时直连
+	this.TxtSshHost = widget.NewEntry()
+	this.TxtSshHost.SetPlaceHolder(I("main-view.ui.TxtSshHost.placeholder"))
+	this.TxtSshPort = widget.NewEntry()
+	this.TxtSshPort.SetPlaceHolder(I("main-view.ui.TxtSshPort.placeholder"))
+	this.TxtSshUser = widget.NewEntry()
+	this.TxtSshUser.SetPlaceHolder(I("main-view.ui.TxtSshUser.placeholder"))
+	this.TxtSshPassword = widget.NewPasswordEntry()
+	this.TxtSshPassword.SetPlaceHolder(I("main-view.ui.TxtSshPassword.placeholder"))
+	// 私钥
+	this.TxtSshKeyFile = widget.NewEntry()
+	this.TxtSshKeyFile.SetPlaceHolder(I("main-view.ui.TxtSshKeyFile.placeholder"))
+	this.BtnChooseSshKeyFile = widget.NewButton(I("main-view.ui.BtnChooseOutputDir.placeholder"), func() { this.chooseFile(this.TxtSshKeyFile) })
+	this.TxtSshKeyPassphrase = widget.NewPasswordEntry()
+	this.TxtSshKeyPassphrase.SetPlaceHolder(I("main-view.ui.TxtSshKeyPassphrase.placeholder"))
+	// known_hosts
+	this.TxtSshKnownHosts = widget.NewEntry()
+	this.TxtSshKnownHosts.SetPlaceHolder(I("main-view.ui.TxtSshKnownHosts.placeholder"))
+	this.BtnChooseSshKnownHosts = widget.NewButton(I("main-view.ui.BtnChooseOutputDir.placeholder"), func() { this.chooseFile(this.TxtSshKnownHosts) })
+
+	this.FormSsh = &widget.Form{Items: []*widget.FormItem{
+		widget.NewFormItem(I("main-view.ui.form.formItem.TxtSshHost.text"), this.TxtSshHost),
+		widget.NewFormItem(I("main-view.ui.form.formItem.TxtSshPort.text"), this.TxtSshPort),
+		widget.NewFormItem(I("main-view.ui.form.formItem.TxtSshUser.text"), this.TxtSshUser),
+		widget.NewFormItem(I("main-view.ui.form.formItem.TxtSshPassword.text"), this.TxtSshPassword),
+		widget.NewFormItem(I("main-view.ui.form.formItem.TxtSshKeyFile.text"), container.NewBorder(nil, nil, nil, this.BtnChooseSshKeyFile, this.TxtSshKeyFile)),
+		widget.NewFormItem(I("main-view.ui.form.formItem.TxtSshKeyPassphrase.text"), this.TxtSshKeyPassphrase),
+		widget.NewFormItem(I("main-view.ui.form.formItem.TxtSshKnownHosts.text"), container.NewBorder(nil, nil, nil, this.BtnChooseSshKnownHosts, this.TxtSshKnownHosts)),
+	}}
+
+	this.AccAdvanced = widget.NewAccordion(
+		widget.NewAccordionItem(I("main-view.ui.AccAdvanced.title"), this.FormAdvanced),
+		widget.NewAccordionItem(I("main-view.ui.AccSsh.title"), this.FormSsh),
+	)
+
+	/* 凭据库 */
+	this.BtnCredentialStore = widget.NewButtonWithIcon("", theme.LoginIcon(), this.btnCredentialStore_onClicked)
+	this.refreshCredentialStoreButton()
+
 	/* 语言选择 */
 	this.SelLocale = widget.NewSelect(utils.I18nGetAvailableLocales(), this.selLocale_onChanged)
 	this.SelLocale.SetSelected(utils.I18nGetCurrentLocale())
 
 	// 根容器
 	rootContainer := container.NewPadded(container.NewVBox(
-		container.NewHBox(this.LblTitle, layout.NewSpacer(), this.SelLocale),
+		container.NewHBox(this.LblTitle, layout.NewSpacer(), this.BtnCredentialStore, this.SelLocale),
 		widget.NewSeparator(),
+		container.NewBorder(nil, nil, nil, container.NewHBox(this.BtnFavourite, this.BtnRemoveHistory), this.SelHistory),
+		container.NewBorder(nil, nil, this.ChkRawDsn, nil, this.TxtDsn),
 		this.FormBasic,
+		this.AccAdvanced,
 		widget.NewSeparator(),
-		container.NewBorder(nil, nil, this.BtnTest, container.NewHBox(this.BtnCustomizeGenerate, this.BtnGenerate), nil),
+		container.NewBorder(nil, nil, this.BtnTest, container.NewHBox(this.BtnGenerateCode, this.BtnCustomizeGenerate, this.BtnGenerate), nil),
 	))
 
 	// 窗口尺寸
@@ -252,6 +425,7 @@ func (this *MainView) refreshUILocale() {
 	this.BtnTest.SetText(I("main-view.ui.BtnTest.label"))
 	this.BtnGenerate.SetText(I("main-view.ui.BtnGenerate.label"))
 	this.BtnCustomizeGenerate.SetText(I("main-view.ui.BtnCustomizeGenerate.label"))
+	this.BtnGenerateCode.SetText(I("main-view.ui.BtnGenerateCode.label"))
 
 	// 更新占位符文本
 	this.TxtHost.SetPlaceHolder(I("main-view.ui.TxtHost.placeholder"))
@@ -262,6 +436,30 @@ func (this *MainView) refreshUILocale() {
 	this.TxtDbName.SetPlaceHolder(I("main-view.ui.TxtDbName.placeholder"))
 	this.TxtOutputDir.SetPlaceHolder(I("main-view.ui.TxtOutputDir.placeholder"))
 	this.BtnChooseOutputDir.SetText(I("main-view.ui.BtnChooseOutputDir.placeholder"))
+	this.TxtDsn.SetPlaceHolder(I("main-view.ui.TxtDsn.placeholder"))
+	this.ChkRawDsn.SetText(I("main-view.ui.ChkRawDsn.label"))
+	this.ChkRememberPassword.SetText(I("main-view.ui.ChkRememberPassword.label"))
+	this.SelHistory.PlaceHolder = I("main-view.ui.SelHistory.placeholder")
+	this.SelHistory.Refresh()
+	this.refreshCredentialStoreButton()
+	this.SelSslMode.PlaceHolder = I("main-view.ui.SelSslMode.placeholder")
+	this.SelSslMode.Refresh()
+	this.TxtSslRootCert.SetPlaceHolder(I("main-view.ui.TxtSslRootCert.placeholder"))
+	this.TxtSslCert.SetPlaceHolder(I("main-view.ui.TxtSslCert.placeholder"))
+	this.TxtSslKey.SetPlaceHolder(I("main-view.ui.TxtSslKey.placeholder"))
+	this.TxtSslServerName.SetPlaceHolder(I("main-view.ui.TxtSslServerName.placeholder"))
+	this.BtnChooseSslRootCert.SetText(I("main-view.ui.BtnChooseOutputDir.placeholder"))
+	this.BtnChooseSslCert.SetText(I("main-view.ui.BtnChooseOutputDir.placeholder"))
+	this.BtnChooseSslKey.SetText(I("main-view.ui.BtnChooseOutputDir.placeholder"))
+	this.TxtSshHost.SetPlaceHolder(I("main-view.ui.TxtSshHost.placeholder"))
+	this.TxtSshPort.SetPlaceHolder(I("main-view.ui.TxtSshPort.placeholder"))
+	this.TxtSshUser.SetPlaceHolder(I("main-view.ui.TxtSshUser.placeholder"))
+	this.TxtSshPassword.SetPlaceHolder(I("main-view.ui.TxtSshPassword.placeholder"))
+	this.TxtSshKeyFile.SetPlaceHolder(I("main-view.ui.TxtSshKeyFile.placeholder"))
+	this.TxtSshKeyPassphrase.SetPlaceHolder(I("main-view.ui.TxtSshKeyPassphrase.placeholder"))
+	this.TxtSshKnownHosts.SetPlaceHolder(I("main-view.ui.TxtSshKnownHosts.placeholder"))
+	this.BtnChooseSshKeyFile.SetText(I("main-view.ui.BtnChooseOutputDir.placeholder"))
+	this.BtnChooseSshKnownHosts.SetText(I("main-view.ui.BtnChooseOutputDir.placeholder"))
 
 	// 更新表单项标签
 	if len(this.FormBasic.Items) >= 8 {
@@ -278,6 +476,29 @@ func (this *MainView) refreshUILocale() {
 		this.FormBasic.Refresh()
 	}
 
+	// 更新高级选项
+	if len(this.FormAdvanced.Items) >= 5 {
+		this.FormAdvanced.Items[0].Text = I("main-view.ui.form.formItem.SelSslMode.text")
+		this.FormAdvanced.Items[1].Text = I("main-view.ui.form.formItem.TxtSslRootCert.text")
+		this.FormAdvanced.Items[2].Text = I("main-view.ui.form.formItem.TxtSslCert.text")
+		this.FormAdvanced.Items[3].Text = I("main-view.ui.form.formItem.TxtSslKey.text")
+		this.FormAdvanced.Items[4].Text = I("main-view.ui.form.formItem.TxtSslServerName.text")
+		this.FormAdvanced.Refresh()
+	}
+	if len(this.FormSsh.Items) >= 7 {
+		this.FormSsh.Items[0].Text = I("main-view.ui.form.formItem.TxtSshHost.text")
+		this.FormSsh.Items[1].Text = I("main-view.ui.form.formItem.TxtSshPort.text")
+		this.FormSsh.Items[2].Text = I("main-view.ui.form.formItem.TxtSshUser.text")
+		this.FormSsh.Items[3].Text = I("main-view.ui.form.formItem.TxtSshPassword.text")
+		this.FormSsh.Items[4].Text = I("main-view.ui.form.formItem.TxtSshKeyFile.text")
+		this.FormSsh.Items[5].Text = I("main-view.ui.form.formItem.TxtSshKeyPassphrase.text")
+		this.FormSsh.Items[6].Text = I("main-view.ui.form.formItem.TxtSshKnownHosts.text")
+		this.FormSsh.Refresh()
+	}
+	this.AccAdvanced.Items[0].Title = I("main-view.ui.AccAdvanced.title")
+	this.AccAdvanced.Items[1].Title = I("main-view.ui.AccSsh.title")
+	this.AccAdvanced.Refresh()
+
 	// 更新标签文本
 	// 如果有其他标签也需要更新，可以在这里添加
 }
@@ -314,20 +535,24 @@ func (this *MainView) initDebug(selected string) {
 		host = "localhost"
 	}
 	if "" == port {
-		if "MySQL" == selected {
+		if "MySQL" == selected || "MariaDB" == selected {
 			port = "3306"
+		} else if "TiDB" == selected {
+			port = "4000"
+		} else if "ClickHouse" == selected {
+			port = "9000"
 		} else if "PostgresSQL" == selected {
 			port = "5432"
 		} else if "SQLServer" == selected {
 			port = "1433"
 		} else if "Oracle" == selected {
 			port = "1521"
-		} else if "SQLite" == selected {
+		} else if "SQLite" == selected || "DuckDB" == selected {
 			port = "0"
 		}
 	}
 	if "" == username {
-		if "MySQL" == selected {
+		if "MySQL" == selected || "MariaDB" == selected || "TiDB" == selected {
 			username = "root"
 		} else if "PostgresSQL" == selected {
 			username = "postgres"
@@ -335,7 +560,9 @@ func (this *MainView) initDebug(selected string) {
 			username = "sa"
 		} else if "Oracle" == selected {
 			username = "c##cc"
-		} else if "SQLite" == selected {
+		} else if "ClickHouse" == selected {
+			username = "default"
+		} else if "SQLite" == selected || "DuckDB" == selected {
 			username = ""
 		}
 	}
@@ -348,7 +575,7 @@ func (this *MainView) initDebug(selected string) {
 	this.TxtPassword.SetText(password)
 
 	this.SelDbType.SetSelected(selected)
-	if "MySQL" == selected {
+	if "MySQL" == selected || "MariaDB" == selected || "TiDB" == selected {
 		this.TxtUsername.SetText(username)
 		this.TxtPort.SetText(port)
 		this.TxtDbName.SetText("student")
@@ -373,7 +600,12 @@ func (this *MainView) initDebug(selected string) {
 		this.TxtDbName.SetText("c##cc")
 		this.SelCharset.SetSelected("utf8mb4")
 		this.SelOutputFormat.SetSelected("xlsx")
-	} else if "SQLite" == selected {
+	} else if "ClickHouse" == selected {
+		this.TxtUsername.SetText(username)
+		this.TxtPort.SetText(port)
+		this.TxtDbName.SetText("default")
+		this.SelOutputFormat.SetSelected("xlsx")
+	} else if "SQLite" == selected || "DuckDB" == selected {
 		this.TxtPort.SetText(port)
 		this.TxtDbName.SetText("")
 		this.SelCharset.SetSelected("utf8mb4")
@@ -390,6 +622,25 @@ func (this *MainView) Show() {
 }
 
 func (this *MainView) validateForm() error {
+	// SSH 隧道
+	if err := this.validateSshForm(); nil != err {
+		return err
+	}
+
+	// 连接串模式只校验连接串和输出目录
+	if this.ChkRawDsn.Checked {
+		if "" == strings.TrimSpace(this.TxtDsn.Text) {
+			return errors.New(I("main-view.msg.error.dsnRequired"))
+		}
+		if _, err := configs.ParseDSN(this.TxtDsn.Text); nil != err {
+			return errors.New(Id("main-view.msg.error.invalidDsn", map[string]interface{}{"Error": err.Error()}))
+		}
+		if "" == strings.TrimSpace(this.TxtOutputDir.Text) {
+			return errors.New(I("main-view.msg.error.outputDirRequired"))
+		}
+		return nil
+	}
+
 	// 地址
 	if "" == strings.TrimSpace(this.TxtHost.Text) {
 		return errors.New(I("main-view.msg.error.databaseAddressRequired"))
@@ -432,8 +683,42 @@ func (this *MainView) validateForm() error {
 	return nil
 }
 
+// validateSshForm 校验 SSH 隧道设置，未填写跳板机地址时不校验
+func (this *MainView) validateSshForm() error {
+	if "" == strings.TrimSpace(this.TxtSshHost.Text) {
+		return nil
+	}
+	if "" != strings.TrimSpace(this.TxtSshPort.Text) {
+		p, err := strconv.Atoi(strings.TrimSpace(this.TxtSshPort.Text))
+		if err != nil || 0 >= p || 65535 < p {
+			return errors.New(I("main-view.msg.error.invalidSshPort"))
+		}
+	}
+	if "" == strings.TrimSpace(this.TxtSshUser.Text) {
+		return errors.New(I("main-view.msg.error.sshUserRequired"))
+	}
+	if "" == this.TxtSshPassword.Text && "" == strings.TrimSpace(this.TxtSshKeyFile.Text) {
+		return errors.New(I("main-view.msg.error.sshAuthRequired"))
+	}
+	return nil
+}
+
 // createDatabaseConfig 创建数据库配置
 func (this *MainView) createDatabaseConfig() (*configs.DatabaseConfig, error) {
+	// 连接串模式
+	if this.ChkRawDsn.Checked {
+		dbConfig, err := configs.ParseDSN(this.TxtDsn.Text)
+		if nil != err {
+			return nil, errors.New(Id("main-view.msg.error.invalidDsn", map[string]interface{}{"Error": err.Error()}))
+		}
+		this.applyTLSOptions(dbConfig)
+		if err = this.applySshOptions(dbConfig); nil != err {
+			return nil, err
+		}
+		this.applyCredentialOptions(dbConfig)
+		return dbConfig, nil
+	}
+
 	// 端口类型转换
 	var port = 0
 	if !this.TxtPort.Disabled() {
@@ -455,10 +740,24 @@ func (this *MainView) createDatabaseConfig() (*configs.DatabaseConfig, error) {
 		Charset:  this.SelCharset.Selected,
 		Database: this.TxtDbName.Text,
 	}
+	this.applyTLSOptions(dbConfig)
+	if err := this.applySshOptions(dbConfig); nil != err {
+		return nil, err
+	}
+	this.applyCredentialOptions(dbConfig)
 
 	return dbConfig, nil
 }
 
+// applyTLSOptions 将高级选项中的 TLS/SSL 设置写入数据库配置
+func (this *MainView) applyTLSOptions(dbConfig *configs.DatabaseConfig) {
+	dbConfig.SSLMode = this.SelSslMode.Selected
+	dbConfig.SSLRootCert = strings.TrimSpace(this.TxtSslRootCert.Text)
+	dbConfig.SSLCert = strings.TrimSpace(this.TxtSslCert.Text)
+	dbConfig.SSLKey = strings.TrimSpace(this.TxtSslKey.Text)
+	dbConfig.SSLServerName = strings.TrimSpace(this.TxtSslServerName.Text)
+}
+
 // changeDisplayMode 修改显示模式
 func (this *MainView) changeDisplayMode(selected string) {
 	// 获取显示控件map
@@ -499,6 +798,40 @@ func (this *MainView) changeDisplayMode(selected string) {
 	}
 }
 
+// changeConnectionMode 切换表单模式与连接串模式
+func (this *MainView) changeConnectionMode(rawDsn bool) {
+	if rawDsn {
+		// 连接串模式下禁用全部连接控件
+		for _, control := range []fyne.Disableable{this.SelDbType, this.TxtHost, this.TxtPort, this.TxtService, this.TxtUsername, this.TxtPassword, this.TxtDbName, this.SelCharset} {
+			control.Disable()
+		}
+		this.TxtDsn.Enable()
+	} else {
+		this.TxtDsn.Disable()
+		this.SelDbType.Enable()
+		this.changeDisplayMode(this.SelDbType.Selected)
+	}
+
+	if nil != this.FormBasic {
+		this.FormBasic.Refresh()
+	}
+}
+
+// fillForm 使用数据库配置填写表单
+func (this *MainView) fillForm(dbConfig *configs.DatabaseConfig) {
+	// 先切换类型，类型变更会重置端口
+	this.SelDbType.SetSelected(dbConfig.Type)
+	this.TxtHost.SetText(dbConfig.Host)
+	this.TxtPort.SetText(strconv.Itoa(dbConfig.Port))
+	this.TxtService.SetText(dbConfig.Service)
+	this.TxtUsername.SetText(dbConfig.Username)
+	this.TxtPassword.SetText(dbConfig.Password)
+	this.TxtDbName.SetText(dbConfig.Database)
+	if "" != dbConfig.Charset {
+		this.SelCharset.SetSelected(dbConfig.Charset)
+	}
+}
+
 // changePort 端口选择变更事件处理函数
 func (this *MainView) changePort(selected string) {
 	// 默认端口
@@ -524,6 +857,93 @@ func (this *MainView) selDbType_onChanged(value string) {
 	this.changePort(value)
 }
 
+// chkRawDsn_onChanged 连接串模式复选框变更事件处理函数
+// 切回表单模式时，用已填写的连接串回填表单（附加参数仅在连接串模式下生效）
+// checked: 是否使用连接串
+func (this *MainView) chkRawDsn_onChanged(checked bool) {
+	if !checked && "" != strings.TrimSpace(this.TxtDsn.Text) {
+		if dbConfig, err := configs.ParseDSN(this.TxtDsn.Text); nil == err {
+			this.fillForm(dbConfig)
+		}
+	}
+	this.changeConnectionMode(checked)
+}
+
+// selHistory_onChanged 连接历史下拉框选择变更事件处理函数
+func (this *MainView) selHistory_onChanged(value string) {
+	if profile := this.getSelectedProfile(); nil != profile {
+		this.restoreProfile(profile)
+	}
+}
+
+// btnFavourite_onClicked 收藏/重命名按钮点击事件处理函数
+func (this *MainView) btnFavourite_onClicked() {
+	profile := this.getSelectedProfile()
+	if nil == profile {
+		dialog.ShowInformation(I("main-view.msg.info"), I("main-view.msg.error.historyNotSelected"), (*this.Window))
+		return
+	}
+
+	chkFavourite := widget.NewCheck(I("main-view.ui.dialog.favourite.ChkFavourite.label"), nil)
+	chkFavourite.SetChecked(true)
+	txtName := widget.NewEntry()
+	txtName.SetText(profile.Name)
+	txtName.SetPlaceHolder(I("main-view.ui.dialog.favourite.TxtName.placeholder"))
+
+	dialog.ShowForm(I("main-view.ui.dialog.favourite.title"), I("main-view.ui.dialog.unlock.confirm"), I("main-view.ui.dialog.unlock.dismiss"), []*widget.FormItem{
+		widget.NewFormItem(I("main-view.ui.dialog.favourite.TxtName.text"), txtName),
+		widget.NewFormItem("", chkFavourite),
+	}, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		this.history.SetFavourite(profile, chkFavourite.Checked, strings.TrimSpace(txtName.Text))
+		this.refreshHistory(profile)
+	}, (*this.Window))
+}
+
+// btnRemoveHistory_onClicked 删除连接记录按钮点击事件处理函数
+func (this *MainView) btnRemoveHistory_onClicked() {
+	profile := this.getSelectedProfile()
+	if nil == profile {
+		dialog.ShowInformation(I("main-view.msg.info"), I("main-view.msg.error.historyNotSelected"), (*this.Window))
+		return
+	}
+
+	dialog.ShowConfirm(I("main-view.msg.info"), Id("main-view.msg.confirm.removeHistory", map[string]interface{}{"Title": profile.GetTitle()}), func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		this.history.Remove(profile)
+		this.refreshHistory(nil)
+	}, (*this.Window))
+}
+
+// chkRememberPassword_onChanged 记住密码复选框变更事件处理函数
+// 勾选时若凭据库未解锁，先要求解锁，取消则撤销勾选
+func (this *MainView) chkRememberPassword_onChanged(checked bool) {
+	if !checked || configs.GetCredentialStore().IsUnlocked() {
+		return
+	}
+	this.showUnlockDialog(func(unlocked bool) {
+		if !unlocked {
+			this.ChkRememberPassword.SetChecked(false)
+		}
+	})
+}
+
+// btnCredentialStore_onClicked 凭据库按钮点击事件处理函数，未解锁时解锁，已解锁时锁定
+func (this *MainView) btnCredentialStore_onClicked() {
+	store := configs.GetCredentialStore()
+	if store.IsUnlocked() {
+		store.Lock()
+		this.ChkRememberPassword.SetChecked(false)
+		this.refreshCredentialStoreButton()
+		return
+	}
+	this.showUnlockDialog(func(bool) {})
+}
+
 // selCharset_onChanged 字符集下拉框选择变更事件处理函数
 // 当用户在字符集下拉框中选择不同选项时触发此函数
 // value: 用户选择的字符集值
@@ -538,6 +958,11 @@ func (this *MainView) selOutputFormat_onChanged(value string) {
 
 // btnChooseOutputDir_onClicked 选择输出目录按钮点击事件处理函数
 func (this *MainView) btnChooseOutputDir_onClicked() {
+	this.chooseFolder(this.TxtOutputDir)
+}
+
+// chooseFolder 选择目录，并将路径写入输入框
+func (this *MainView) chooseFolder(entry *widget.Entry) {
 	// 创建目录选择对话框
 	dialog.ShowFolderOpen(func(list fyne.ListableURI, err error) {
 		if err != nil {
@@ -548,7 +973,225 @@ func (this *MainView) btnChooseOutputDir_onClicked() {
 			return // 用户取消了选择
 		}
 		// 获取选择的目录路径
-		this.TxtOutputDir.SetText(list.Path())
+		entry.SetText(list.Path())
+	}, (*this.Window))
+}
+
+// applySshOptions 将 SSH 隧道设置写入数据库配置
+func (this *MainView) applySshOptions(dbConfig *configs.DatabaseConfig) error {
+	dbConfig.SSHHost = strings.TrimSpace(this.TxtSshHost.Text)
+	if "" == dbConfig.SSHHost {
+		return nil
+	}
+
+	// 端口为空时使用默认端口
+	if sshPort := strings.TrimSpace(this.TxtSshPort.Text); "" != sshPort {
+		p, err := strconv.Atoi(sshPort)
+		if err != nil {
+			return errors.New(I("main-view.msg.error.invalidSshPort"))
+		}
+		dbConfig.SSHPort = p
+	}
+	dbConfig.SSHUser = strings.TrimSpace(this.TxtSshUser.Text)
+	dbConfig.SSHPassword = this.TxtSshPassword.Text
+	dbConfig.SSHKeyFile = strings.TrimSpace(this.TxtSshKeyFile.Text)
+	dbConfig.SSHKeyPassphrase = this.TxtSshKeyPassphrase.Text
+	dbConfig.SSHKnownHosts = strings.TrimSpace(this.TxtSshKnownHosts.Text)
+	return nil
+}
+
+// applyCredentialOptions 密码留空且凭据库已保存时，改为连接时从凭据库读取
+func (this *MainView) applyCredentialOptions(dbConfig *configs.DatabaseConfig) {
+	store := configs.GetCredentialStore()
+	if !store.IsUnlocked() {
+		return
+	}
+
+	if key := configs.CredentialKey(dbConfig); "" == dbConfig.Password && store.Has(key) {
+		dbConfig.PasswordRef = key
+	}
+	if "" != dbConfig.SSHHost {
+		if key := configs.SSHCredentialKey(dbConfig); "" == dbConfig.SSHPassword && store.Has(key) {
+			dbConfig.SSHPasswordRef = key
+		}
+	}
+}
+
+// rememberCredentials 连接成功后，按需将密码保存到凭据库；
+// remember 为界面上“记住密码”的选中状态，需在界面线程读取后传入
+func (this *MainView) rememberCredentials(dbConfig *configs.DatabaseConfig, remember bool) {
+	store := configs.GetCredentialStore()
+	if !remember || !store.IsUnlocked() {
+		return
+	}
+
+	if "" != dbConfig.Password {
+		if err := store.Set(configs.CredentialKey(dbConfig), dbConfig.Password); nil != err {
+			slog.Error("保存密码失败", "error", err)
+		}
+	}
+	if "" != dbConfig.SSHHost && "" != dbConfig.SSHPassword {
+		if err := store.Set(configs.SSHCredentialKey(dbConfig), dbConfig.SSHPassword); nil != err {
+			slog.Error("保存 SSH 密码失败", "error", err)
+		}
+	}
+}
+
+// createConnectionProfile 根据当前界面创建连接记录，需在界面线程及连接之前调用（连接时可能修正数据库类型，记录保存界面选择的类型）
+func (this *MainView) createConnectionProfile(dbConfig *configs.DatabaseConfig) *ConnectionProfile {
+	dsn := ""
+	if this.ChkRawDsn.Checked {
+		dsn = strings.TrimSpace(this.TxtDsn.Text)
+	}
+	return NewConnectionProfile(dbConfig, dsn, this.SelOutputFormat.Selected, this.TxtOutputDir.Text)
+}
+
+// saveHistory 连接成功后记录到连接历史
+func (this *MainView) saveHistory(profile *ConnectionProfile) {
+	fyne.Do(func() {
+		this.history.Add(profile)
+		this.refreshHistory(profile)
+	})
+}
+
+// refreshHistory 刷新连接历史下拉框，selected 为需要保持选中的记录（不触发恢复）
+func (this *MainView) refreshHistory(selected *ConnectionProfile) {
+	profileList := this.history.GetProfileList()
+	optionList := make([]string, 0, len(profileList))
+	for _, profile := range profileList {
+		optionList = append(optionList, profile.GetTitle())
+	}
+	this.SelHistory.Options = optionList
+
+	this.SelHistory.Selected = ""
+	if nil != selected {
+		this.SelHistory.Selected = selected.GetTitle()
+	}
+	this.SelHistory.Refresh()
+}
+
+// getSelectedProfile 获取下拉框选中的连接记录
+func (this *MainView) getSelectedProfile() *ConnectionProfile {
+	index := this.SelHistory.SelectedIndex()
+	profileList := this.history.GetProfileList()
+	if 0 > index || len(profileList) <= index {
+		return nil
+	}
+	return profileList[index]
+}
+
+// restoreProfile 使用连接记录填写界面，密码需重新输入或由凭据库提供
+func (this *MainView) restoreProfile(profile *ConnectionProfile) {
+	dbConfig := profile.Config
+
+	// 连接方式
+	if "" != profile.Dsn {
+		this.TxtDsn.SetText(profile.Dsn)
+		this.ChkRawDsn.SetChecked(true)
+	} else {
+		this.TxtDsn.SetText("")
+		this.ChkRawDsn.SetChecked(false)
+		this.fillForm(&dbConfig)
+	}
+
+	// TLS/SSL
+	if "" == dbConfig.SSLMode {
+		this.SelSslMode.ClearSelected()
+	} else {
+		this.SelSslMode.SetSelected(dbConfig.SSLMode)
+	}
+	this.TxtSslRootCert.SetText(dbConfig.SSLRootCert)
+	this.TxtSslCert.SetText(dbConfig.SSLCert)
+	this.TxtSslKey.SetText(dbConfig.SSLKey)
+	this.TxtSslServerName.SetText(dbConfig.SSLServerName)
+
+	// SSH 隧道
+	this.TxtSshHost.SetText(dbConfig.SSHHost)
+	this.TxtSshPort.SetText("")
+	if 0 != dbConfig.SSHPort {
+		this.TxtSshPort.SetText(strconv.Itoa(dbConfig.SSHPort))
+	}
+	this.TxtSshUser.SetText(dbConfig.SSHUser)
+	this.TxtSshPassword.SetText("")
+	this.TxtSshKeyFile.SetText(dbConfig.SSHKeyFile)
+	this.TxtSshKeyPassphrase.SetText("")
+	this.TxtSshKnownHosts.SetText(dbConfig.SSHKnownHosts)
+
+	// 输出
+	if "" != profile.OutputFormat {
+		this.SelOutputFormat.SetSelected(profile.OutputFormat)
+	}
+	if "" != profile.OutputDir {
+		this.TxtOutputDir.SetText(profile.OutputDir)
+	}
+}
+
+// refreshCredentialStoreButton 按凭据库状态更新按钮
+func (this *MainView) refreshCredentialStoreButton() {
+	if configs.GetCredentialStore().IsUnlocked() {
+		this.BtnCredentialStore.SetText(I("main-view.ui.BtnCredentialStore.lock"))
+		this.BtnCredentialStore.SetIcon(theme.LogoutIcon())
+	} else {
+		this.BtnCredentialStore.SetText(I("main-view.ui.BtnCredentialStore.unlock"))
+		this.BtnCredentialStore.SetIcon(theme.LoginIcon())
+	}
+}
+
+// showUnlockDialog 显示凭据库解锁对话框，本次运行期间只需解锁一次
+// onUnlocked: 解锁成功后的回调，取消或失败时传入 false
+func (this *MainView) showUnlockDialog(onUnlocked func(bool)) {
+	store := configs.GetCredentialStore()
+
+	txtMasterPassword := widget.NewPasswordEntry()
+	txtKeyFile := widget.NewEntry()
+	txtKeyFile.SetPlaceHolder(I("main-view.ui.dialog.unlock.TxtKeyFile.placeholder"))
+	btnChooseKeyFile := widget.NewButton(I("main-view.ui.BtnChooseOutputDir.placeholder"), func() { this.chooseFile(txtKeyFile) })
+
+	// 首次使用时提示将创建凭据库
+	title := I("main-view.ui.dialog.unlock.title")
+	if !store.Exists() {
+		title = I("main-view.ui.dialog.unlock.createTitle")
+	}
+
+	dialog.ShowForm(title, I("main-view.ui.dialog.unlock.confirm"), I("main-view.ui.dialog.unlock.dismiss"), []*widget.FormItem{
+		widget.NewFormItem(I("main-view.ui.dialog.unlock.TxtMasterPassword.text"), txtMasterPassword),
+		widget.NewFormItem(I("main-view.ui.dialog.unlock.TxtKeyFile.text"), container.NewBorder(nil, nil, nil, btnChooseKeyFile, txtKeyFile)),
+	}, func(confirmed bool) {
+		if !confirmed {
+			onUnlocked(false)
+			return
+		}
+
+		// 优先使用密钥文件
+		var err error
+		if keyFile := strings.TrimSpace(txtKeyFile.Text); "" != keyFile {
+			err = store.UnlockWithKeyFile(keyFile)
+		} else {
+			err = store.Unlock([]byte(txtMasterPassword.Text))
+		}
+		if nil != err {
+			dialog.ShowError(errors.New(Id("main-view.msg.error.credentialStoreUnlockFailed", map[string]interface{}{"Error": err.Error()})), (*this.Window))
+			onUnlocked(false)
+			return
+		}
+
+		this.refreshCredentialStoreButton()
+		onUnlocked(true)
+	}, (*this.Window))
+}
+
+// chooseFile 选择文件，并将路径写入输入框
+func (this *MainView) chooseFile(entry *widget.Entry) {
+	dialog.ShowFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(errors.New(I("main-view.msg.error.fileOpenError")), (*this.Window))
+			return
+		}
+		if reader == nil {
+			return // 用户取消了选择
+		}
+		defer reader.Close()
+		entry.SetText(reader.URI().Path())
 	}, (*this.Window))
 }
 
@@ -575,6 +1218,8 @@ func (this *MainView) btnTest_onClicked() {
 		dialog.ShowError(errors.New(Id("main-view.msg.error.invalidDatabaseConfig", map[string]interface{}{"Error": err.Error()})), (*this.Window))
 		return
 	}
+	rememberPassword := this.ChkRememberPassword.Checked
+	profile := this.createConnectionProfile(dbConfig)
 
 	// 使用现有的 InitDatabase 方法初始化数据库连接
 	db, err := configs.InitDatabase(dbConfig)
@@ -582,6 +1227,7 @@ func (this *MainView) btnTest_onClicked() {
 		dialog.ShowError(errors.New(Id("main-view.msg.error.databaseConnectingFailed", map[string]interface{}{"Error": err.Error()})), (*this.Window))
 		return
 	}
+	defer configs.CloseDatabase(db)
 
 	// 执行简单的查询来测试连接是否正常
 	sqlDB, err := db.DB()
@@ -595,6 +1241,8 @@ func (this *MainView) btnTest_onClicked() {
 		dialog.ShowError(errors.New(Id("main-view.msg.error.databasePingingFailed", map[string]interface{}{"Error": err.Error()})), (*this.Window))
 		return
 	}
+	this.rememberCredentials(dbConfig, rememberPassword)
+	this.saveHistory(profile)
 
 	dialog.ShowInformation(
 		I("main-view.msg.info"),
@@ -626,13 +1274,46 @@ func (this *MainView) btnCustomizeGenerate_onClicked() {
 		return
 	}
 
+	// 选择表后生成数据
+	this.selectTables(dbConfig, func(selectedTableNameList []string) {
+		this.generateDictWithProgress(dbConfig, outputDirPath, outputFormat, selectedTableNameList, nil)
+	})
+}
+
+// selectTables 读取数据库信息并显示表选择窗口，选择完成后调用 onSelected
+func (this *MainView) selectTables(dbConfig *configs.DatabaseConfig, onSelected func(selectedTableNameList []string)) {
+	// 界面状态在后台协程开始前读取
+	rememberPassword := this.ChkRememberPassword.Checked
+	profile := this.createConnectionProfile(dbConfig)
+
 	// 获取数据库信息
-	dbInfo, err := getDatabaseInfo(dbConfig, nil)
-	if err != nil {
-		dialog.ShowError(errors.New(Id("main-view.msg.error.databaseInfoFetchingFailed", map[string]interface{}{"Error": err.Error()})), (*this.Window))
-		return
-	}
+	ctx, cancel := context.WithCancel(context.Background())
+	progressDialog := NewProgressDialog(*this.Window, I("main-view.ui.dialog.progress.title"), cancel)
+	progressDialog.Show()
+	go func() {
+		defer cancel()
+		dbInfo, err := getDatabaseInfo(ctx, dbConfig, nil, progressDialog.Update)
+		progressDialog.Hide()
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		if err != nil {
+			fyne.Do(func() {
+				dialog.ShowError(errors.New(Id("main-view.msg.error.databaseInfoFetchingFailed", map[string]interface{}{"Error": err.Error()})), (*this.Window))
+			})
+			return
+		}
+		this.rememberCredentials(dbConfig, rememberPassword)
+		this.saveHistory(profile)
+
+		fyne.Do(func() {
+			this.showSearchView(dbInfo, onSelected)
+		})
+	}()
+}
 
+// showSearchView 显示表选择窗口
+func (this *MainView) showSearchView(dbInfo *models.DatabaseInfo, onSelected func(selectedTableNameList []string)) {
 	// 提取数据库信息
 	result := make(map[string]string, len(dbInfo.TableNameList))
 	for _, tableInfo := range dbInfo.TableMap {
@@ -645,28 +1326,49 @@ func (this *MainView) btnCustomizeGenerate_onClicked() {
 	searchView.OnFinished = func(selectedTableNameList []string) {
 		slog.Debug("selectedMap: %+v", selectedTableNameList)
 
-		go func() {
+		// 主窗口获得焦点
+		(*this.Window).RequestFocus()
+		onSelected(selectedTableNameList)
+	}
+	// 显示选择窗口
+	searchView.Show()
+}
+
+// generateDictWithProgress 在后台生成文档并显示进度，取消时删除不完整的文件；onSucceeded 在生成成功后于后台协程中调用
+func (this *MainView) generateDictWithProgress(dbConfig *configs.DatabaseConfig, outputDirPath string, outputFormat string, selectedTableNameList []string, onSucceeded func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	progressDialog := NewProgressDialog(*this.Window, I("main-view.ui.dialog.progress.title"), cancel)
+	progressDialog.Show()
+
+	go func() {
+		defer cancel()
+		// 生成
+		savePath, err := generateDict(ctx, dbConfig, outputDirPath, outputFormat, selectedTableNameList, progressDialog.Update)
+		progressDialog.Hide()
+		if errors.Is(err, context.Canceled) {
 			fyne.Do(func() {
-				// 主窗口获得焦点
-				(*this.Window).RequestFocus()
+				dialog.ShowInformation(I("main-view.msg.info"), I("main-view.msg.error.generateCanceled"), (*this.Window))
 			})
-
-			// 生成数据
-			savePath, err := generateDict(dbConfig, outputDirPath, outputFormat, selectedTableNameList)
-			if err != nil {
+			return
+		}
+		if err != nil {
+			fyne.Do(func() {
 				dialog.ShowError(errors.New(Id("main-view.msg.error.generateDictError", map[string]interface{}{"Error": err.Error()})), (*this.Window))
-				return
-			}
+			})
+			return
+		}
+		if nil != onSucceeded {
+			onSucceeded()
+		}
 
+		fyne.Do(func() {
 			dialog.ShowInformation(
 				I("main-view.msg.info"),
 				Id("main-view.msg.error.documentCreatingSucceeded", map[string]interface{}{"Message": savePath}),
 				(*this.Window),
 			)
-		}()
-	}
-	// 显示选择窗口
-	searchView.Show()
+		})
+	}()
 }
 
 // btnGenerate_onClicked 生成文档按钮点击事件处理函数
@@ -689,18 +1391,12 @@ func (this *MainView) btnGenerate_onClicked() {
 		return
 	}
 
-	go func() {
-		// 生成
-		savePath, err := generateDict(dbConfig, outputDirPath, outputFormat, nil)
-		if err != nil {
-			dialog.ShowError(errors.New(Id("main-view.msg.error.generateDictError", map[string]interface{}{"Error": err.Error()})), (*this.Window))
-			return
-		}
+	// 界面状态在后台协程开始前读取
+	rememberPassword := this.ChkRememberPassword.Checked
+	profile := this.createConnectionProfile(dbConfig)
 
-		dialog.ShowInformation(
-			I("main-view.msg.info"),
-			Id("main-view.msg.error.documentCreatingSucceeded", map[string]interface{}{"Message": savePath}),
-			(*this.Window),
-		)
-	}()
+	this.generateDictWithProgress(dbConfig, outputDirPath, outputFormat, nil, func() {
+		this.rememberCredentials(dbConfig, rememberPassword)
+		this.saveHistory(profile)
+	})
 }
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"goDict/configs"
+	"goDict/services"
+	"strings"
+)
+
+// 代码生成选项在 Preferences 中的键前缀
+const generateCodePreferenceKey = "main-view.generate-code."
+
+// btnGenerateCode_onClicked 生成代码按钮点击事件处理函数
+func (this *MainView) btnGenerateCode_onClicked() {
+	// 初始化数据库配置
+	dbConfig, err := this.createDatabaseConfig()
+	if err != nil {
+		dialog.ShowError(errors.New(Id("main-view.msg.error.databaseInitializingFailed", map[string]interface{}{"Error": err.Error()})), (*this.Window))
+		return
+	}
+
+	// 验证表单
+	err = this.validateForm()
+	if err != nil {
+		dialog.ShowError(errors.New(I("main-view.msg.error.validateFormError")), (*this.Window))
+		return
+	}
+
+	// 选择表后设置生成选项
+	this.selectTables(dbConfig, func(selectedTableNameList []string) {
+		this.showGenerateCodeDialog(dbConfig, selectedTableNameList)
+	})
+}
+
+// showGenerateCodeDialog 显示代码生成选项，确认后生成，选项保存到 Preferences
+func (this *MainView) showGenerateCodeDialog(dbConfig *configs.DatabaseConfig, selectedTableNameList []string) {
+	preferences := (*this.App).Preferences()
+	option := services.NewGenerateOption(this.TxtOutputDir.Text, "")
+
+	// 输入框，默认值为上次使用的选项
+	newEntry := func(name string, value string, placeholder string) *widget.Entry {
+		entry := widget.NewEntry()
+		entry.SetPlaceHolder(placeholder)
+		entry.SetText(preferences.StringWithFallback(generateCodePreferenceKey+name, value))
+		return entry
+	}
+	txtOutputDir := newEntry("outputDir", option.OutputDirPath, "")
+	txtModulePath := newEntry("modulePath", option.ModulePath, I("main-view.ui.dialog.generateCode.TxtModulePath.placeholder"))
+	txtModelPackage := newEntry("modelPackage", option.ModelPackage, "")
+	txtServicePackage := newEntry("servicePackage", option.ServicePackage, "")
+	txtDaoPackage := newEntry("daoPackage", option.DaoPackage, "")
+	txtQueryPackage := newEntry("queryPackage", option.QueryPackage, "")
+	txtHandlerPackage := newEntry("handlerPackage", option.HandlerPackage, I("main-view.ui.dialog.generateCode.TxtHandlerPackage.placeholder"))
+	txtTypeScriptPackage := newEntry("typeScriptPackage", option.TypeScriptPackage, I("main-view.ui.dialog.generateCode.TxtTypeScriptPackage.placeholder"))
+	txtJavaPackage := newEntry("javaPackage", option.JavaPackage, I("main-view.ui.dialog.generateCode.TxtJavaPackage.placeholder"))
+	txtJavaPackageName := newEntry("javaPackageName", option.JavaPackageName, "")
+	txtProtoPackage := newEntry("protoPackage", option.ProtoPackage, I("main-view.ui.dialog.generateCode.TxtProtoPackage.placeholder"))
+	txtProtoPackageName := newEntry("protoPackageName", option.ProtoPackageName, "")
+	txtTemplateDir := newEntry("templateDir", option.TemplateDirPath, I("main-view.ui.dialog.generateCode.TxtTemplateDir.placeholder"))
+	btnChooseOutputDir := widget.NewButtonWithIcon("", theme.FolderOpenIcon(), func() {
+		this.chooseFolder(txtOutputDir)
+	})
+	btnChooseTemplateDir := widget.NewButtonWithIcon("", theme.FolderOpenIcon(), func() {
+		this.chooseFolder(txtTemplateDir)
+	})
+
+	formDialog := dialog.NewForm(I("main-view.ui.dialog.generateCode.title"), I("main-view.ui.dialog.generateCode.confirm"), I("main-view.ui.dialog.generateCode.dismiss"), []*widget.FormItem{
+		widget.NewFormItem(I("main-view.ui.dialog.generateCode.TxtOutputDir.text"), container.NewBorder(nil, nil, nil, btnChooseOutputDir, txtOutputDir)),
+		widget.NewFormItem(I("main-view.ui.dialog.generateCode.TxtModulePath.text"), txtModulePath),
+		widget.NewFormItem(I("main-view.ui.dialog.generateCode.TxtModelPackage.text"), txtModelPackage),
+		widget.NewFormItem(I("main-view.ui.dialog.generateCode.TxtServicePackage.text"), txtServicePackage),
+		widget.NewFormItem(I("main-view.ui.dialog.generateCode.TxtDaoPackage.text"), txtDaoPackage),
+		widget.NewFormItem(I("main-view.ui.dialog.generateCode.TxtQueryPackage.text"), txtQueryPackage),
+		widget.NewFormItem(I("main-view.ui.dialog.generateCode.TxtHandlerPackage.text"), txtHandlerPackage),
+		widget.NewFormItem(I("main-view.ui.dialog.generateCode.TxtTypeScriptPackage.text"), txtTypeScriptPackage),
+		widget.NewFormItem(I("main-view.ui.dialog.generateCode.TxtJavaPackage.text"), txtJavaPackage),
+		widget.NewFormItem(I("main-view.ui.dialog.generateCode.TxtJavaPackageName.text"), txtJavaPackageName),
+		widget.NewFormItem(I("main-view.ui.dialog.generateCode.TxtProtoPackage.text"), txtProtoPackage),
+		widget.NewFormItem(I("main-view.ui.dialog.generateCode.TxtProtoPackageName.text"), txtProtoPackageName),
+		widget.NewFormItem(I("main-view.ui.dialog.generateCode.TxtTemplateDir.text"), container.NewBorder(nil, nil, nil, btnChooseTemplateDir, txtTemplateDir)),
+	}, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+
+		option.OutputDirPath = strings.TrimSpace(txtOutputDir.Text)
+		option.ModulePath = strings.TrimSpace(txtModulePath.Text)
+		option.ModelPackage = strings.TrimSpace(txtModelPackage.Text)
+		option.ServicePackage = strings.TrimSpace(txtServicePackage.Text)
+		option.DaoPackage = strings.TrimSpace(txtDaoPackage.Text)
+		option.QueryPackage = strings.TrimSpace(txtQueryPackage.Text)
+		option.HandlerPackage = strings.TrimSpace(txtHandlerPackage.Text)
+		option.TypeScriptPackage = strings.TrimSpace(txtTypeScriptPackage.Text)
+		option.JavaPackage = strings.TrimSpace(txtJavaPackage.Text)
+		option.JavaPackageName = strings.TrimSpace(txtJavaPackageName.Text)
+		option.ProtoPackage = strings.TrimSpace(txtProtoPackage.Text)
+		option.ProtoPackageName = strings.TrimSpace(txtProtoPackageName.Text)
+		option.TemplateDirPath = strings.TrimSpace(txtTemplateDir.Text)
+		option.TableNameList = selectedTableNameList
+		if err := option.Validate(); nil != err {
+			dialog.ShowError(errors.New(Id("main-view.msg.error.generateCodeError", map[string]interface{}{"Error": err.Error()})), (*this.Window))
+			return
+		}
+
+		// 保存选项
+		preferences.SetString(generateCodePreferenceKey+"outputDir", option.OutputDirPath)
+		preferences.SetString(generateCodePreferenceKey+"modulePath", option.ModulePath)
+		preferences.SetString(generateCodePreferenceKey+"modelPackage", option.ModelPackage)
+		preferences.SetString(generateCodePreferenceKey+"servicePackage", option.ServicePackage)
+		preferences.SetString(generateCodePreferenceKey+"daoPackage", option.DaoPackage)
+		preferences.SetString(generateCodePreferenceKey+"queryPackage", option.QueryPackage)
+		preferences.SetString(generateCodePreferenceKey+"handlerPackage", option.HandlerPackage)
+		preferences.SetString(generateCodePreferenceKey+"typeScriptPackage", option.TypeScriptPackage)
+		preferences.SetString(generateCodePreferenceKey+"javaPackage", option.JavaPackage)
+		preferences.SetString(generateCodePreferenceKey+"javaPackageName", option.JavaPackageName)
+		preferences.SetString(generateCodePreferenceKey+"protoPackage", option.ProtoPackage)
+		preferences.SetString(generateCodePreferenceKey+"protoPackageName", option.ProtoPackageName)
+		preferences.SetString(generateCodePreferenceKey+"templateDir", option.TemplateDirPath)
+
+		this.generateCodeWithProgress(dbConfig, option)
+	}, (*this.Window))
+	formDialog.Resize(fyne.NewSize(520, 0))
+	formDialog.Show()
+}
+
+// generateCodeWithProgress 在后台生成代码并显示进度，可取消
+func (this *MainView) generateCodeWithProgress(dbConfig *configs.DatabaseConfig, option *services.GenerateOption) {
+	ctx, cancel := context.WithCancel(context.Background())
+	progressDialog := NewProgressDialog(*this.Window, I("main-view.ui.dialog.progress.title"), cancel)
+	progressDialog.Show()
+
+	go func() {
+		defer cancel()
+		pathList, err := generateCode(ctx, dbConfig, option, progressDialog.Update)
+		progressDialog.Hide()
+		if errors.Is(err, context.Canceled) {
+			fyne.Do(func() {
+				dialog.ShowInformation(I("main-view.msg.info"), I("main-view.msg.error.generateCodeCanceled"), (*this.Window))
+			})
+			return
+		}
+		if err != nil {
+			fyne.Do(func() {
+				dialog.ShowError(errors.New(Id("main-view.msg.error.generateCodeError", map[string]interface{}{"Error": err.Error()})), (*this.Window))
+			})
+			return
+		}
+
+		fyne.Do(func() {
+			dialog.ShowInformation(
+				I("main-view.msg.info"),
+				Id("main-view.msg.error.codeGeneratingSucceeded", map[string]interface{}{"Count": len(pathList), "Message": option.OutputDirPath}),
+				(*this.Window),
+			)
+		})
+	}()
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"gorm.io/gorm"
 	"time"
 )
 
@@ -15,7 +16,22 @@ type BaseModel struct {
 	ID        uint      `gorm:"primarykey" json:"id"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
-	//DeletedAt gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
+}
+
+// SoftDeleteModel 软删除，嵌入后删除时只设置 deleted_at，查询时忽略已删除的记录
+type SoftDeleteModel struct {
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
+}
+
+// AuditModel 创建人、修改人，由 BaseDao 从上下文中的用户（见 WithUser）填充
+type AuditModel struct {
+	CreatedBy string `gorm:"size:64" json:"createdBy"`
+	UpdatedBy string `gorm:"size:64" json:"updatedBy"`
+}
+
+// VersionModel 乐观锁版本号，BaseDao.Update 时校验并加一
+type VersionModel struct {
+	Version int64 `gorm:"not null;default:0" json:"version"`
 }
 
 // 添加TableName的默认实现
@@ -0,0 +1,17 @@
+package models
+
+import "context"
+
+// userContextKey 上下文中当前用户的键
+type userContextKey struct{}
+
+// WithUser 将当前用户放入上下文，BaseDao 写入时用于填充 created_by、updated_by
+func WithUser(ctx context.Context, user string) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user)
+}
+
+// GetUser 获取上下文中的当前用户
+func GetUser(ctx context.Context) (string, bool) {
+	user, ok := ctx.Value(userContextKey{}).(string)
+	return user, ok && "" != user
+}
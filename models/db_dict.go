@@ -1,6 +1,9 @@
 package models
 
-import "strings"
+import (
+	"slices"
+	"strings"
+)
 
 // IndexInfo 索引信息结构
 type IndexInfo struct {
@@ -37,6 +40,83 @@ type ColumnInfo struct {
 	IsUnique        bool
 	Default         string
 	Comment         string
+	// 是否生成列（计算列）
+	IsGenerated bool
+	// 生成列表达式
+	GenerationExpression string
+	// 生成列是否存储（否则为虚拟列）
+	IsStored bool
+	// 默认值来源序列
+	SequenceName string
+	// 枚举/集合的可选值（逗号分隔）
+	EnumValues string
+	// 自定义类型的底层定义（域、复合类型等）
+	TypeDefinition string
+	// 字符集
+	CharacterSetName string
+	// 排序规则
+	CollationName string
+	// 方言特有标注，如 JSON、AUTO_RANDOM(5)
+	Annotation string
+	// 压缩编码（ClickHouse），如 CODEC(ZSTD(1))
+	Codec string
+}
+
+// GetEnumValueList 获取枚举/集合的可选值列表
+func (this *ColumnInfo) GetEnumValueList() []string {
+	if "" == this.EnumValues {
+		return []string{}
+	}
+	return strings.Split(this.EnumValues, ", ")
+}
+
+// 字段类型详情（枚举、集合、自定义类型）
+type ColumnTypeDetail struct {
+	DatabaseName   string `json:"database_name"`
+	TableName      string `json:"table_name"`
+	ColumnName     string `json:"column_name"`
+	TypeName       string `json:"type_name"`
+	TypeDefinition string `json:"type_definition"`
+}
+
+// 自定义类型信息（PostgresSQL 枚举、域、复合、范围类型）
+type TypeInfo struct {
+	DatabaseName string `json:"database_name"`
+	SchemaName   string `json:"schema_name"`
+	TypeName     string `json:"type_name"`
+	// enum / domain / composite / range
+	TypeKind       string `json:"type_kind"`
+	TypeDefinition string `json:"type_definition"`
+	Comment        string `json:"comment"`
+}
+
+// 生成列信息
+type GeneratedColumnInfo struct {
+	DatabaseName         string `json:"database_name"`
+	TableName            string `json:"table_name"`
+	ColumnName           string `json:"column_name"`
+	GenerationExpression string `json:"generation_expression"`
+	IsStored             bool   `json:"is_stored"`
+}
+
+// 检查约束信息
+type CheckConstraintInfo struct {
+	DatabaseName   string `json:"database_name"`
+	TableName      string `json:"table_name"`
+	ConstraintName string `json:"constraint_name"`
+	CheckClause    string `json:"check_clause"`
+}
+
+// 序列信息
+type SequenceInfo struct {
+	DatabaseName string `json:"database_name"`
+	SequenceName string `json:"sequence_name"`
+	StartValue   int64  `json:"start_value"`
+	IncrementBy  int64  `json:"increment_by"`
+	// 所属表（可能为空）
+	TableName string `json:"table_name"`
+	// 所属字段（可能为空）
+	ColumnName string `json:"column_name"`
 }
 
 type DecimalSizeInfo struct {
@@ -51,6 +131,84 @@ type TableType struct {
 	TableType    string `json:"table_type"`
 }
 
+// 分区信息
+type PartitionInfo struct {
+	Sort         int    `json:"sort"`
+	DatabaseName string `json:"database_name"`
+	// 父表名
+	TableName     string `json:"table_name"`
+	PartitionName string `json:"partition_name"`
+	// 分区方式：RANGE / LIST / HASH / KEY
+	PartitionStrategy string `json:"partition_strategy"`
+	// 分区键
+	PartitionKey string `json:"partition_key"`
+	// 分区边界
+	PartitionBound string `json:"partition_bound"`
+	// 估计行数
+	RowEstimate int64 `json:"row_estimate"`
+}
+
+// 视图定义
+type ViewDefinition struct {
+	DatabaseName   string `json:"database_name"`
+	TableName      string `json:"table_name"`
+	ViewDefinition string `json:"view_definition"`
+}
+
+// 视图字段依赖（视图字段 <- 基础表字段）
+type ViewDependency struct {
+	ViewColumnName string
+	TableName      string
+	ColumnName     string
+}
+
+// 方言特有标注（MariaDB、TiDB 等），ColumnName 为空时为表标注
+type AnnotationInfo struct {
+	DatabaseName string `json:"database_name"`
+	TableName    string `json:"table_name"`
+	ColumnName   string `json:"column_name"`
+	Annotation   string `json:"annotation"`
+}
+
+// 表引擎信息（MySQL、ClickHouse）
+type TableEngineInfo struct {
+	DatabaseName string `json:"database_name"`
+	TableName    string `json:"table_name"`
+	Engine       string `json:"engine"`
+	PartitionKey string `json:"partition_key"`
+	SortingKey   string `json:"sorting_key"`
+	PrimaryKey   string `json:"primary_key"`
+	// 完整引擎定义，用于解析 TTL
+	EngineFull string `json:"engine_full"`
+}
+
+// 字符集及排序规则信息（库、表、字段共用）
+type CollationInfo struct {
+	DatabaseName     string `json:"database_name"`
+	TableName        string `json:"table_name"`
+	ColumnName       string `json:"column_name"`
+	CharacterSetName string `json:"character_set_name"`
+	CollationName    string `json:"collation_name"`
+}
+
+// 排序规则与库默认值不一致的表
+type CollationMismatchInfo struct {
+	TableName string
+	// 表排序规则（不一致时）
+	CollationName string
+	// 排序规则不一致的字段
+	ColumnList []*ColumnInfo
+}
+
+// GetColumnText 获取不一致字段文本，如 name (utf8mb4_bin), code (latin1_swedish_ci)
+func (this *CollationMismatchInfo) GetColumnText() string {
+	textList := []string{}
+	for _, columnInfo := range this.ColumnList {
+		textList = append(textList, columnInfo.ColumnName+" ("+columnInfo.CollationName+")")
+	}
+	return strings.Join(textList, ", ")
+}
+
 // 表信息结构体
 type TableInfo struct {
 	DatabaseName string
@@ -59,6 +217,67 @@ type TableInfo struct {
 	Comment      string
 	TableType    string // TABLE or VIEW
 	IndexList    []*IndexInfo
+	// 视图定义SQL（仅视图）
+	ViewDefinition string
+	// 视图字段依赖列表（仅视图）
+	ViewDependencyList []*ViewDependency
+	// 检查约束列表
+	CheckConstraintList []*CheckConstraintInfo
+	// 所属序列列表
+	SequenceList []*SequenceInfo
+	// 分区方式（仅分区表）
+	PartitionStrategy string
+	// 分区键（仅分区表）
+	PartitionKey string
+	// 分区列表（仅分区表）
+	PartitionList []*PartitionInfo
+	// 字符集
+	CharacterSetName string
+	// 排序规则
+	CollationName string
+	// 方言特有标注，如 SYSTEM VERSIONED、CLUSTERED
+	AnnotationList []string
+	// 表引擎
+	Engine string
+	// 排序键（ClickHouse）
+	SortingKey string
+	// 主键表达式（ClickHouse）
+	PrimaryKey string
+	// 数据过期表达式（ClickHouse）
+	TTL string
+}
+
+// GetCollationColumnList 获取有排序规则的字段列表
+func (this *TableInfo) GetCollationColumnList() []*ColumnInfo {
+	result := []*ColumnInfo{}
+	for _, columnInfo := range this.ColumnList {
+		if "" != columnInfo.CollationName {
+			result = append(result, columnInfo)
+		}
+	}
+	return result
+}
+
+// GetGeneratedColumnList 获取生成列列表
+func (this *TableInfo) GetGeneratedColumnList() []*ColumnInfo {
+	result := []*ColumnInfo{}
+	for _, columnInfo := range this.ColumnList {
+		if columnInfo.IsGenerated {
+			result = append(result, columnInfo)
+		}
+	}
+	return result
+}
+
+// GetViewDependencyTableNameList 获取视图依赖的基础表名列表（去重）
+func (this *TableInfo) GetViewDependencyTableNameList() []string {
+	result := []string{}
+	for _, dependency := range this.ViewDependencyList {
+		if !slices.Contains(result, dependency.TableName) {
+			result = append(result, dependency.TableName)
+		}
+	}
+	return result
 }
 
 // 数据库信息结构体
@@ -66,6 +285,14 @@ type DatabaseInfo struct {
 	DatabaseName  string
 	TableMap      map[string]TableInfo
 	TableNameList []string
+	// 全库序列
+	SequenceList []*SequenceInfo
+	// 全库自定义类型
+	TypeList []*TypeInfo
+	// 库默认字符集
+	CharacterSetName string
+	// 库默认排序规则
+	CollationName string
 	// 选中的表名
 	selectedTableNameList []string
 }
@@ -129,6 +356,43 @@ func (this *DatabaseInfo) SetSelectedTableNameList(selectedTableNameList []strin
 	}
 }
 
+// GetCollationMismatchList 获取排序规则与库默认值不一致的选中表
+func (this *DatabaseInfo) GetCollationMismatchList() []*CollationMismatchInfo {
+	result := []*CollationMismatchInfo{}
+	// 未获取到库默认排序规则
+	if "" == this.CollationName {
+		return result
+	}
+
+	for _, tableName := range this.GetSelectedTableNameList() {
+		tableInfo, ok := this.TableMap[tableName]
+		if !ok {
+			continue
+		}
+
+		mismatchInfo := &CollationMismatchInfo{
+			TableName:  tableName,
+			ColumnList: []*ColumnInfo{},
+		}
+		// 表排序规则
+		if "" != tableInfo.CollationName && this.CollationName != tableInfo.CollationName {
+			mismatchInfo.CollationName = tableInfo.CollationName
+		}
+		// 字段排序规则
+		for _, columnInfo := range tableInfo.GetCollationColumnList() {
+			if this.CollationName != columnInfo.CollationName {
+				mismatchInfo.ColumnList = append(mismatchInfo.ColumnList, columnInfo)
+			}
+		}
+
+		if "" != mismatchInfo.CollationName || 0 < len(mismatchInfo.ColumnList) {
+			result = append(result, mismatchInfo)
+		}
+	}
+
+	return result
+}
+
 // GetSelectedTableMap 获取选中的表信息map
 func (this *DatabaseInfo) GetSelectedTableMap() map[string]*TableInfo {
 	result := make(map[string]*TableInfo)
@@ -0,0 +1,89 @@
+package models
+
+// FilterOperator 过滤条件的操作符
+type FilterOperator string
+
+const (
+	FilterOperatorEq        FilterOperator = "eq"
+	FilterOperatorNe        FilterOperator = "ne"
+	FilterOperatorGt        FilterOperator = "gt"
+	FilterOperatorGe        FilterOperator = "ge"
+	FilterOperatorLt        FilterOperator = "lt"
+	FilterOperatorLe        FilterOperator = "le"
+	FilterOperatorBetween   FilterOperator = "between"
+	FilterOperatorIn        FilterOperator = "in"
+	FilterOperatorLike      FilterOperator = "like"
+	FilterOperatorIsNull    FilterOperator = "isNull"
+	FilterOperatorIsNotNull FilterOperator = "isNotNull"
+)
+
+// Filter 过滤条件树。
+// 条件节点：Column、Operator、Value，between 的 Value 为两个元素的切片，in 的 Value 为切片，isNull、isNotNull 无 Value；
+// 分组节点：And 中的条件全部满足，或 Or 中的条件满足其一，And、Or 不能同时使用
+type Filter struct {
+	Column   string         `json:"column,omitempty"`
+	Operator FilterOperator `json:"operator,omitempty"`
+	Value    interface{}    `json:"value,omitempty"`
+	And      []*Filter      `json:"and,omitempty"`
+	Or       []*Filter      `json:"or,omitempty"`
+}
+
+// IsGroup 是否分组节点
+func (f *Filter) IsGroup() bool {
+	return 0 < len(f.And) || 0 < len(f.Or)
+}
+
+func Eq(column string, value interface{}) *Filter {
+	return &Filter{Column: column, Operator: FilterOperatorEq, Value: value}
+}
+
+func Ne(column string, value interface{}) *Filter {
+	return &Filter{Column: column, Operator: FilterOperatorNe, Value: value}
+}
+
+func Gt(column string, value interface{}) *Filter {
+	return &Filter{Column: column, Operator: FilterOperatorGt, Value: value}
+}
+
+func Ge(column string, value interface{}) *Filter {
+	return &Filter{Column: column, Operator: FilterOperatorGe, Value: value}
+}
+
+func Lt(column string, value interface{}) *Filter {
+	return &Filter{Column: column, Operator: FilterOperatorLt, Value: value}
+}
+
+func Le(column string, value interface{}) *Filter {
+	return &Filter{Column: column, Operator: FilterOperatorLe, Value: value}
+}
+
+func Between(column string, from interface{}, to interface{}) *Filter {
+	return &Filter{Column: column, Operator: FilterOperatorBetween, Value: []interface{}{from, to}}
+}
+
+func In(column string, valueList ...interface{}) *Filter {
+	return &Filter{Column: column, Operator: FilterOperatorIn, Value: valueList}
+}
+
+// Like 模糊匹配，pattern 中的 % 和 _ 由调用方指定
+func Like(column string, pattern string) *Filter {
+	return &Filter{Column: column, Operator: FilterOperatorLike, Value: pattern}
+}
+
+func IsNull(column string) *Filter {
+	return &Filter{Column: column, Operator: FilterOperatorIsNull}
+}
+
+func IsNotNull(column string) *Filter {
+	return &Filter{Column: column, Operator: FilterOperatorIsNotNull}
+}
+
+// And 全部满足
+func And(filterList ...*Filter) *Filter {
+	return &Filter{And: filterList}
+}
+
+// Or 满足其一
+func Or(filterList ...*Filter) *Filter {
+	return &Filter{Or: filterList}
+}
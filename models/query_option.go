@@ -8,7 +8,14 @@ const (
 type QueryOption struct {
 	PageNum  *int
 	PageSize *int
-	Sorting  []string
+	// 排序，如 "name"、"created_at desc"，列须为模型中的字段
+	Sorting []string
+	// 过滤条件，列须为模型中的字段
+	Filter *Filter
+	// 游标分页的游标，来自上次结果的 NextCursor 或 PrevCursor，为空时查询第一页
+	Cursor string
+	// 不统计总数，查询结果的 TotalCount（及 PageCount）为 nil
+	SkipCount bool
 }
 
 func NewQueryOption() *QueryOption {
@@ -37,6 +44,21 @@ func (q *QueryOption) SetSorting(sorting []string) *QueryOption {
 	return q
 }
 
+func (q *QueryOption) SetFilter(filter *Filter) *QueryOption {
+	q.Filter = filter
+	return q
+}
+
+func (q *QueryOption) SetCursor(cursor string) *QueryOption {
+	q.Cursor = cursor
+	return q
+}
+
+func (q *QueryOption) SetSkipCount(skipCount bool) *QueryOption {
+	q.SkipCount = skipCount
+	return q
+}
+
 func (q *QueryOption) GetOffset() int {
 	// 页码
 	pageNum := DEFAULT_PAGE_NO
@@ -4,19 +4,24 @@ import "math"
 
 // Pagination 分页响应结构
 type QueryResult[T IEntity] struct {
-	Data       []*T  `json:"data"`       // 数据列表
-	PageNum    int   `json:"pageNum"`    // 当前页码
-	PageSize   int   `json:"pageSize"`   // 每页大小
-	TotalCount int64 `json:"totalCount"` // 总数
-	PageCount  int   `json:"pageCount"`  // 总页数
+	Data       []*T   `json:"data"`                 // 数据列表
+	PageNum    int    `json:"pageNum"`              // 当前页码
+	PageSize   int    `json:"pageSize"`             // 每页大小
+	TotalCount *int64 `json:"totalCount,omitempty"` // 总数，不统计时为 nil
+	PageCount  *int   `json:"pageCount,omitempty"`  // 总页数，不统计时为 nil
 }
 
-func NewQueryResult[T IEntity](data []*T, totalCount int64, queryOptions *QueryOption) *QueryResult[T] {
+// NewQueryResult 创建分页结果，totalCount 为 nil（未统计总数）时 PageCount 也为 nil
+func NewQueryResult[T IEntity](data []*T, totalCount *int64, queryOptions *QueryOption) *QueryResult[T] {
 	pageNum := queryOptions.GetPageNum()
 	pageSize := queryOptions.GetPageSize()
 
 	// 计算共计多少页
-	pageCount := int(math.Ceil(float64(totalCount) / float64(pageSize)))
+	var pageCount *int
+	if nil != totalCount {
+		pageCount = new(int)
+		*pageCount = int(math.Ceil(float64(*totalCount) / float64(pageSize)))
+	}
 
 	return &QueryResult[T]{
 		Data:       data,
@@ -26,3 +31,12 @@ func NewQueryResult[T IEntity](data []*T, totalCount int64, queryOptions *QueryO
 		PageCount:  pageCount,
 	}
 }
+
+// CursorQueryResult 游标分页响应结构
+type CursorQueryResult[T IEntity] struct {
+	Data       []*T   `json:"data"`                 // 数据列表
+	PageSize   int    `json:"pageSize"`             // 每页大小
+	NextCursor string `json:"nextCursor,omitempty"` // 下一页的游标，为空时没有下一页
+	PrevCursor string `json:"prevCursor,omitempty"` // 上一页的游标，为空时没有上一页
+	TotalCount *int64 `json:"totalCount,omitempty"` // 总数，不统计时为 nil
+}
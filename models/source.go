@@ -0,0 +1,8 @@
+package models
+
+import "embed"
+
+// SourceFS 生成代码时输出到目标项目模型目录的源码：基础模型、上下文用户、过滤条件、查询选项及结果
+//
+//go:embed base_model.go context_user.go query_filter.go query_option.go query_result.go
+var SourceFS embed.FS
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"goDict/services"
+	"sync"
+	"time"
+)
+
+// ProgressDialog 生成进度对话框，显示当前表名、已用时间，可取消
+type ProgressDialog struct {
+	// 对话框
+	dialog dialog.Dialog
+	// 进度条
+	progressBar *widget.ProgressBar
+	// 当前表名
+	lblName *widget.Label
+	// 已用时间
+	lblElapsed *widget.Label
+	// 取消按钮
+	btnCancel *widget.Button
+
+	// 取消任务
+	cancel context.CancelFunc
+	// 开始时间
+	startedAt time.Time
+	// 停止计时
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+// NewProgressDialog 创建进度对话框，点击取消时调用 cancel
+func NewProgressDialog(window fyne.Window, title string, cancel context.CancelFunc) *ProgressDialog {
+	instance := &ProgressDialog{
+		progressBar: widget.NewProgressBar(),
+		lblName:     widget.NewLabel(I("main-view.ui.dialog.progress.waiting")),
+		lblElapsed:  widget.NewLabel(""),
+		cancel:      cancel,
+		done:        make(chan struct{}),
+	}
+	instance.lblName.Truncation = fyne.TextTruncateEllipsis
+	instance.btnCancel = widget.NewButton(I("main-view.ui.dialog.progress.BtnCancel.text"), instance.btnCancel_onClicked)
+
+	content := container.NewVBox(
+		instance.lblName,
+		instance.progressBar,
+		instance.lblElapsed,
+		container.NewCenter(instance.btnCancel),
+	)
+	instance.dialog = dialog.NewCustomWithoutButtons(title, content, window)
+	instance.dialog.Resize(fyne.NewSize(420, 0))
+	return instance
+}
+
+// Show 显示对话框并开始计时
+func (this *ProgressDialog) Show() {
+	this.startedAt = time.Now()
+	this.refreshElapsed()
+	this.dialog.Show()
+
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-this.done:
+				return
+			case <-ticker.C:
+				fyne.Do(this.refreshElapsed)
+			}
+		}
+	}()
+}
+
+// Hide 停止计时并关闭对话框，可在任意协程调用
+func (this *ProgressDialog) Hide() {
+	this.doneOnce.Do(func() {
+		close(this.done)
+	})
+	fyne.Do(this.dialog.Hide)
+}
+
+// Update 更新进度，可在任意协程调用，签名与 services.ProgressFunc 一致
+func (this *ProgressDialog) Update(phase string, current int, total int, name string) {
+	messageId := "main-view.ui.dialog.progress.rendering"
+	switch phase {
+	case services.ProgressPhaseMetadata:
+		messageId = "main-view.ui.dialog.progress.metadata"
+	case services.ProgressPhaseCode:
+		messageId = "main-view.ui.dialog.progress.code"
+	}
+	text := Id(messageId, map[string]interface{}{"Name": name, "Current": current, "Total": total})
+
+	fyne.Do(func() {
+		// 取消中不再刷新
+		if this.btnCancel.Disabled() {
+			return
+		}
+		this.lblName.SetText(text)
+		this.progressBar.Max = float64(total)
+		this.progressBar.SetValue(float64(current))
+	})
+}
+
+// refreshElapsed 刷新已用时间
+func (this *ProgressDialog) refreshElapsed() {
+	elapsed := time.Since(this.startedAt).Round(time.Second)
+	this.lblElapsed.SetText(Id("main-view.ui.dialog.progress.elapsed", map[string]interface{}{"Elapsed": elapsed.String()}))
+}
+
+// btnCancel_onClicked 取消按钮点击事件处理函数
+func (this *ProgressDialog) btnCancel_onClicked() {
+	this.btnCancel.Disable()
+	this.lblName.SetText(I("main-view.ui.dialog.progress.canceling"))
+	this.cancel()
+}
@@ -9,15 +9,20 @@ import (
 // IBaseService 泛型Service接口
 type IBaseService[T models.IEntity] interface {
 	Insert(ctx context.Context, entity *T) error
+	InsertBatch(ctx context.Context, entities []*T, batchSize int) error
+	Upsert(ctx context.Context, entity *T, conflictColumns ...string) error
 	DeleteById(ctx context.Context, id uint) error
 	DeleteByIdList(ctx context.Context, ids []uint) error
 	Update(ctx context.Context, entity *T) error
 	SelectById(ctx context.Context, id uint) (*T, error)
-	SelectByIdList(ctx context.Context, ids []uint, queryOptions *models.QueryOption) ([]*T, error)
-	SelectByQuery(ctx context.Context, query *T, queryOptions *models.QueryOption) ([]*T, error)
+	SelectByIdList(ctx context.Context, ids []uint, queryOptions *models.QueryOption) (*models.QueryResult[T], error)
+	SelectByQuery(ctx context.Context, query *T, queryOptions *models.QueryOption) (*models.QueryResult[T], error)
+	SelectByCursor(ctx context.Context, query *T, queryOptions *models.QueryOption) (*models.CursorQueryResult[T], error)
+	ForEach(ctx context.Context, query *T, queryOptions *models.QueryOption, fn func(entity *T) error) error
 	BeginTransaction(ctx context.Context) (context.Context, error)
 	CommitTransaction(ctx context.Context) error
 	RollbackTransaction(ctx context.Context) error
+	WithTransaction(ctx context.Context, txFunc func(txCtx context.Context) error) error
 }
 
 // BaseService 泛型Service实现
@@ -25,6 +30,9 @@ type BaseService[T models.IEntity] struct {
 	dao dao.IBaseDao[T]
 }
 
+// 确保 BaseService 实现所有 IBaseService 方法
+var _ IBaseService[models.IEntity] = (*BaseService[models.IEntity])(nil)
+
 // NewBaseService 创建新的BaseService实例
 func NewBaseService[T models.IEntity](dao dao.IBaseDao[T]) *BaseService[T] {
 	return &BaseService[T]{
@@ -37,6 +45,16 @@ func (s *BaseService[T]) Insert(ctx context.Context, entity *T) error {
 	return s.dao.Insert(ctx, entity)
 }
 
+// InsertBatch 批量插入记录
+func (s *BaseService[T]) InsertBatch(ctx context.Context, entities []*T, batchSize int) error {
+	return s.dao.InsertBatch(ctx, entities, batchSize)
+}
+
+// Upsert 插入或更新记录
+func (s *BaseService[T]) Upsert(ctx context.Context, entity *T, conflictColumns ...string) error {
+	return s.dao.Upsert(ctx, entity, conflictColumns...)
+}
+
 // DeleteById 根据ID删除
 func (s *BaseService[T]) DeleteById(ctx context.Context, id uint) error {
 	return s.dao.DeleteById(ctx, id)
@@ -67,7 +85,33 @@ func (s *BaseService[T]) SelectByQuery(ctx context.Context, query *T, queryOptio
 	return s.dao.SelectByQuery(ctx, query, queryOptions)
 }
 
+// SelectByCursor 根据条件游标分页查询
+func (s *BaseService[T]) SelectByCursor(ctx context.Context, query *T, queryOptions *models.QueryOption) (*models.CursorQueryResult[T], error) {
+	return s.dao.SelectByCursor(ctx, query, queryOptions)
+}
+
+// ForEach 逐行遍历查询结果
+func (s *BaseService[T]) ForEach(ctx context.Context, query *T, queryOptions *models.QueryOption, fn func(entity *T) error) error {
+	return s.dao.ForEach(ctx, query, queryOptions, fn)
+}
+
 // WithTransaction 执行事务操作
 func (s *BaseService[T]) WithTransaction(ctx context.Context, txFunc func(txCtx context.Context) error) error {
 	return s.dao.WithTransaction(ctx, txFunc)
 }
+
+// BeginTransaction 开启事务，返回的上下文传给其他 Service 后在同一事务中执行；
+// 已在事务中时创建保存点
+func (s *BaseService[T]) BeginTransaction(ctx context.Context) (context.Context, error) {
+	return dao.BeginTransaction(ctx, s.dao.GetDb())
+}
+
+// CommitTransaction 提交 BeginTransaction 开启的事务
+func (s *BaseService[T]) CommitTransaction(ctx context.Context) error {
+	return dao.CommitTransaction(ctx)
+}
+
+// RollbackTransaction 回滚 BeginTransaction 开启的事务
+func (s *BaseService[T]) RollbackTransaction(ctx context.Context) error {
+	return dao.RollbackTransaction(ctx)
+}
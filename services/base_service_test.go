@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"goDict/dao"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"testing"
+)
+
+// txOrder、txStock 测试模型，由两个 Service 在同一事务中写入
+type txOrder struct {
+	ID   uint `gorm:"primarykey"`
+	Code string
+}
+
+func (txOrder) TableName() string {
+	return "tx_order"
+}
+
+func (e txOrder) GetID() uint {
+	return e.ID
+}
+
+type txStock struct {
+	ID       uint `gorm:"primarykey"`
+	Quantity int
+}
+
+func (txStock) TableName() string {
+	return "tx_stock"
+}
+
+func (e txStock) GetID() uint {
+	return e.ID
+}
+
+func TestServiceSharedTransaction(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	if nil != err {
+		t.Fatal(err)
+	}
+	// 内存库每个连接独立，固定使用一个连接
+	sqlDB, err := db.DB()
+	if nil != err {
+		t.Fatal(err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	if err = db.AutoMigrate(&txOrder{}, &txStock{}); nil != err {
+		t.Fatal(err)
+	}
+	orderService := NewBaseService[txOrder](dao.NewBaseDao[txOrder](db))
+	stockService := NewBaseService[txStock](dao.NewBaseDao[txStock](db))
+	ctx := context.Background()
+
+	// writeBoth 由一个 Service 开启事务，两个 Service 在同一上下文中写入
+	writeBoth := func(code string) context.Context {
+		txCtx, err := orderService.BeginTransaction(ctx)
+		if nil != err {
+			t.Fatal(err)
+		}
+		if err = orderService.Insert(txCtx, &txOrder{Code: code}); nil != err {
+			t.Fatal(err)
+		}
+		if err = stockService.Insert(txCtx, &txStock{Quantity: 1}); nil != err {
+			t.Fatal(err)
+		}
+		return txCtx
+	}
+	countBoth := func() (int64, int64) {
+		var orderCount, stockCount int64
+		db.Model(&txOrder{}).Count(&orderCount)
+		db.Model(&txStock{}).Count(&stockCount)
+		return orderCount, stockCount
+	}
+
+	// 回滚时两个 Service 的修改都撤销
+	if err = stockService.RollbackTransaction(writeBoth("a")); nil != err {
+		t.Fatal(err)
+	}
+	if orderCount, stockCount := countBoth(); 0 != orderCount || 0 != stockCount {
+		t.Errorf("after rollback: order count = %d, stock count = %d, want 0 and 0", orderCount, stockCount)
+	}
+
+	// 提交时两个 Service 的修改一起生效
+	if err = stockService.CommitTransaction(writeBoth("b")); nil != err {
+		t.Fatal(err)
+	}
+	if orderCount, stockCount := countBoth(); 1 != orderCount || 1 != stockCount {
+		t.Errorf("after commit: order count = %d, stock count = %d, want 1 and 1", orderCount, stockCount)
+	}
+}
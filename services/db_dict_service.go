@@ -1,13 +1,21 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"goDict/configs"
 	"goDict/models"
+	"goDict/utils"
 	"gorm.io/gorm"
 	"log/slog"
+	"os"
+	"regexp"
 	"slices"
 	"sort"
+	"strings"
+	"sync"
+	"time"
 )
 
 // 支持的格式
@@ -17,16 +25,101 @@ var SUPPORTED_FORMAT = map[string]bool{
 	"xlsx": true,
 }
 
+// 默认值中的序列引用，如 nextval('public.seq_id'::regclass)
+var nextvalRegexp = regexp.MustCompile(`(?i)nextval\('(?:[^'.]+\.)?"?([^'"]+)"?'`)
+
+// 进度阶段
+const (
+	// 读取元数据
+	ProgressPhaseMetadata = "metadata"
+	// 生成文档
+	ProgressPhaseRendering = "rendering"
+	// 生成代码
+	ProgressPhaseCode = "code"
+)
+
+// ProgressFunc 进度回调，current 从 1 开始，name 为当前处理的表名
+type ProgressFunc func(phase string, current int, total int, name string)
+
+// report 报告进度，未指定回调时忽略
+func (this ProgressFunc) report(phase string, current int, total int, name string) {
+	if nil != this {
+		this(phase, current, total, name)
+	}
+}
+
+// 元数据查询默认并发数
+const defaultMetadataQueryConcurrency = 4
+
+// 单个元数据查询默认超时时间
+const defaultMetadataQueryTimeout = 2 * time.Minute
+
 type DbDictService struct {
 	DB *gorm.DB
+	// 元数据查询并发数
+	QueryConcurrency int
+	// 单个元数据查询超时时间，0 表示不限制
+	QueryTimeout time.Duration
 }
 
 func NewDbDictService(db *gorm.DB) *DbDictService {
 	return &DbDictService{
-		DB: db,
+		DB:               db,
+		QueryConcurrency: defaultMetadataQueryConcurrency,
+		QueryTimeout:     defaultMetadataQueryTimeout,
 	}
 }
 
+// metadataQuery 元数据查询任务
+type metadataQuery struct {
+	// 名称，用于日志及错误信息
+	name string
+	// 查询，service 已绑定该查询的上下文
+	run func(service *DbDictService) error
+}
+
+// runMetadataQueryList 以有限并发执行元数据查询，每个查询单独超时，汇总全部失败后返回
+func (this *DbDictService) runMetadataQueryList(ctx context.Context, queryList []metadataQuery) error {
+	semaphore := make(chan struct{}, max(1, this.QueryConcurrency))
+	errorList := make([]error, len(queryList))
+
+	var waitGroup sync.WaitGroup
+	for i, query := range queryList {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+
+			// 等待空闲
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+				errorList[i] = fmt.Errorf("%s: %w", query.name, ctx.Err())
+				return
+			}
+
+			// 单个查询超时
+			queryCtx, cancel := ctx, context.CancelFunc(func() {})
+			if 0 < this.QueryTimeout {
+				queryCtx, cancel = context.WithTimeout(ctx, this.QueryTimeout)
+			}
+			defer cancel()
+
+			startedAt := time.Now()
+			err := query.run(&DbDictService{DB: this.DB.WithContext(queryCtx)})
+			if nil != err {
+				slog.Error("元数据查询失败", "query", query.name, "elapsed", time.Since(startedAt), "error", err)
+				errorList[i] = fmt.Errorf("%s: %w", query.name, err)
+				return
+			}
+			slog.Debug("元数据查询完成", "query", query.name, "elapsed", time.Since(startedAt))
+		}()
+	}
+	waitGroup.Wait()
+
+	return errors.Join(errorList...)
+}
+
 // buildTableInfo 获取表信息
 func (this *DbDictService) buildTableInfo(
 	databaseName string,
@@ -35,6 +128,17 @@ func (this *DbDictService) buildTableInfo(
 	tableColumnInfoMap *map[string][]*models.ColumnInfo,
 	indexInfoListMap *map[string][]*models.IndexInfo,
 	tableCommemtMap *map[string]string,
+	viewDefinitionMap *map[string]string,
+	checkConstraintMap *map[string][]*models.CheckConstraintInfo,
+	generatedColumnMap *map[string]map[string]*models.GeneratedColumnInfo,
+	sequenceList []*models.SequenceInfo,
+	partitionMap *map[string][]*models.PartitionInfo,
+	columnTypeDetailMap *map[string]map[string]*models.ColumnTypeDetail,
+	typeList []*models.TypeInfo,
+	tableCollationMap *map[string]*models.CollationInfo,
+	columnCollationMap *map[string]map[string]*models.CollationInfo,
+	annotationMap *map[string][]*models.AnnotationInfo,
+	tableEngineMap *map[string]*models.TableEngineInfo,
 ) (*models.TableInfo, error) {
 	// 获取对象类型
 	tableType := (*tableTypeMap)[tableName]
@@ -61,6 +165,94 @@ func (this *DbDictService) buildTableInfo(
 		return nil, errors.New("未找到表字段信息")
 	}
 
+	// 生成列
+	if generatedColumnInfoMap, ok := (*generatedColumnMap)[tableName]; ok {
+		for _, columnInfo := range columnList {
+			if generatedColumnInfo, ok := generatedColumnInfoMap[columnInfo.ColumnName]; ok {
+				columnInfo.IsGenerated = true
+				columnInfo.GenerationExpression = generatedColumnInfo.GenerationExpression
+				columnInfo.IsStored = generatedColumnInfo.IsStored
+			}
+		}
+	}
+
+	// 枚举、集合及自定义类型
+	if columnTypeDetailInfoMap, ok := (*columnTypeDetailMap)[tableName]; ok {
+		for _, columnInfo := range columnList {
+			columnTypeDetail, ok := columnTypeDetailInfoMap[columnInfo.ColumnName]
+			if !ok {
+				continue
+			}
+			// MySQL 枚举/集合，如 enum('a','b')
+			if "enum" == columnTypeDetail.TypeName || "set" == columnTypeDetail.TypeName {
+				columnInfo.EnumValues = strings.Join(parseEnumValueList(columnTypeDetail.TypeDefinition), ", ")
+				continue
+			}
+			// 自定义类型以类型名称显示
+			columnInfo.DataType = columnTypeDetail.TypeName
+			columnInfo.TypeDefinition = columnTypeDetail.TypeDefinition
+			// PostgresSQL 从全库自定义类型中获取定义
+			for _, typeInfo := range typeList {
+				if typeInfo.TypeName != columnTypeDetail.TypeName {
+					continue
+				}
+				if "enum" == typeInfo.TypeKind {
+					columnInfo.EnumValues = typeInfo.TypeDefinition
+				} else {
+					columnInfo.TypeDefinition = typeInfo.TypeDefinition
+				}
+				break
+			}
+		}
+	}
+
+	// 字段字符集及排序规则
+	if columnCollationInfoMap, ok := (*columnCollationMap)[tableName]; ok {
+		for _, columnInfo := range columnList {
+			if collationInfo, ok := columnCollationInfoMap[columnInfo.ColumnName]; ok {
+				columnInfo.CharacterSetName = collationInfo.CharacterSetName
+				columnInfo.CollationName = collationInfo.CollationName
+			}
+		}
+	}
+
+	// 字段标注
+	tableAnnotationList := []string{}
+	for _, annotationInfo := range (*annotationMap)[tableName] {
+		if "" == annotationInfo.ColumnName {
+			tableAnnotationList = append(tableAnnotationList, annotationInfo.Annotation)
+			continue
+		}
+		for _, columnInfo := range columnList {
+			if columnInfo.ColumnName == annotationInfo.ColumnName {
+				columnInfo.Annotation = annotationInfo.Annotation
+			}
+		}
+	}
+
+	// 所属序列
+	tableSequenceList := []*models.SequenceInfo{}
+	for _, sequenceInfo := range sequenceList {
+		if tableName != sequenceInfo.TableName {
+			continue
+		}
+		tableSequenceList = append(tableSequenceList, sequenceInfo)
+		for _, columnInfo := range columnList {
+			if columnInfo.ColumnName == sequenceInfo.ColumnName {
+				columnInfo.SequenceName = sequenceInfo.SequenceName
+			}
+		}
+	}
+	// 未声明所属关系的序列，从默认值 nextval('xxx') 中提取
+	for _, columnInfo := range columnList {
+		if "" != columnInfo.SequenceName {
+			continue
+		}
+		if matchList := nextvalRegexp.FindStringSubmatch(columnInfo.Default); 1 < len(matchList) {
+			columnInfo.SequenceName = matchList[1]
+		}
+	}
+
 	// 创建表信息
 	tableInfo := &models.TableInfo{
 		DatabaseName: databaseName,
@@ -69,37 +261,175 @@ func (this *DbDictService) buildTableInfo(
 		TableType:    tableType,
 		Comment:      tableCommemt,
 		IndexList:    indexInfoList,
+		// 检查约束
+		CheckConstraintList: (*checkConstraintMap)[tableName],
+		// 所属序列
+		SequenceList: tableSequenceList,
+		// 表标注
+		AnnotationList: tableAnnotationList,
+	}
+
+	// 表引擎
+	if tableEngineInfo, ok := (*tableEngineMap)[tableName]; ok {
+		tableInfo.Engine = tableEngineInfo.Engine
+		if "" != tableEngineInfo.PartitionKey {
+			tableInfo.PartitionKey = tableEngineInfo.PartitionKey
+		}
+		tableInfo.SortingKey = tableEngineInfo.SortingKey
+		tableInfo.PrimaryKey = tableEngineInfo.PrimaryKey
+		tableInfo.TTL = parseClickHouseTTL(tableEngineInfo.EngineFull)
+	}
+
+	// 表字符集及排序规则
+	if collationInfo, ok := (*tableCollationMap)[tableName]; ok {
+		tableInfo.CharacterSetName = collationInfo.CharacterSetName
+		tableInfo.CollationName = collationInfo.CollationName
+	}
+
+	// 分区表
+	if partitionList, ok := (*partitionMap)[tableName]; ok && 0 < len(partitionList) {
+		tableInfo.PartitionStrategy = partitionList[0].PartitionStrategy
+		tableInfo.PartitionKey = partitionList[0].PartitionKey
+		tableInfo.PartitionList = partitionList
+	}
+
+	// 视图：定义SQL及字段依赖
+	if viewDefinition, ok := (*viewDefinitionMap)[tableName]; ok && "" != viewDefinition {
+		tableInfo.ViewDefinition = utils.FormatSql(viewDefinition)
+		tableInfo.ViewDependencyList = parseViewDependencyList(viewDefinition, *tableColumnInfoMap)
 	}
 
 	return tableInfo, nil
 }
 
-// GetDatabaseInfo 生成数据库信息
-func (this *DbDictService) GetDatabaseInfo(dbConfig *configs.DatabaseConfig, selectedTableNameList []string) (*models.DatabaseInfo, error) {
+// GetDatabaseInfo 生成数据库信息，ctx 取消时中断查询并返回 ctx.Err()
+func (this *DbDictService) GetDatabaseInfo(ctx context.Context, dbConfig *configs.DatabaseConfig, selectedTableNameList []string, progress ProgressFunc) (*models.DatabaseInfo, error) {
 	// 获取migrator对象
-	migrator := this.DB.Migrator()
+	migrator := this.DB.WithContext(ctx).Migrator()
 
 	// 获取数据库名称（schema）
 	databaseName := migrator.CurrentDatabase()
-	// 获取全库对象类型（按表聚合）
-	tableTypeMap, err := this.getTableType(dbConfig)
-	if err != nil {
-		return nil, err
-	}
-	// 获取全库索引（按表聚合）
-	indexInfoListMap, err := this.getTableIndexInfoMap(dbConfig)
-	if err != nil {
-		return nil, err
+
+	var (
+		tableTypeMap        map[string]string
+		indexInfoListMap    map[string][]*models.IndexInfo
+		tableCommentMap     map[string]string
+		tableColumnInfoMap  map[string][]*models.ColumnInfo
+		viewDefinitionMap   map[string]string
+		checkConstraintMap  map[string][]*models.CheckConstraintInfo
+		generatedColumnMap  map[string]map[string]*models.GeneratedColumnInfo
+		sequenceList        []*models.SequenceInfo
+		partitionMap        map[string][]*models.PartitionInfo
+		columnTypeDetailMap map[string]map[string]*models.ColumnTypeDetail
+		typeList            []*models.TypeInfo
+		databaseCollation   *models.CollationInfo
+		tableCollationMap   map[string]*models.CollationInfo
+		columnCollationMap  map[string]map[string]*models.CollationInfo
+		annotationMap       map[string][]*models.AnnotationInfo
+		tableEngineMap      map[string]*models.TableEngineInfo
+	)
+	// 各查询互不依赖，并发执行，每个查询只写入自己的结果
+	queryList := []metadataQuery{
+		// 全库对象类型（按表聚合）
+		{"tableType", func(service *DbDictService) (err error) {
+			tableTypeMap, err = service.getTableType(dbConfig)
+			return err
+		}},
+		// 全库索引（按表聚合）
+		{"tableIndex", func(service *DbDictService) (err error) {
+			indexInfoListMap, err = service.getTableIndexInfoMap(dbConfig)
+			return err
+		}},
+		// 全库表注释（按表聚合）
+		{"tableComment", func(service *DbDictService) (err error) {
+			tableCommentMap, err = service.getTableComment(dbConfig)
+			return err
+		}},
+		// 全库字段类型（按表聚合）
+		{"tableColumn", func(service *DbDictService) (err error) {
+			tableColumnInfoMap, err = service.getTableColumnInfoMap(dbConfig)
+			return err
+		}},
+		// 全库视图定义（按视图聚合）
+		{"viewDefinition", func(service *DbDictService) (err error) {
+			viewDefinitionMap, err = service.getViewDefinitionMap(dbConfig)
+			return err
+		}},
+		// 全库检查约束（按表聚合）
+		{"checkConstraint", func(service *DbDictService) (err error) {
+			checkConstraintMap, err = service.getCheckConstraintMap(dbConfig)
+			return err
+		}},
+		// 全库生成列（按表聚合）
+		{"generatedColumn", func(service *DbDictService) (err error) {
+			generatedColumnMap, err = service.getGeneratedColumnMap(dbConfig)
+			return err
+		}},
+		// 全库序列
+		{"sequence", func(service *DbDictService) (err error) {
+			sequenceList, err = service.getSequenceList(dbConfig)
+			return err
+		}},
+		// 全库分区（按父表聚合）
+		{"partition", func(service *DbDictService) (err error) {
+			partitionMap, err = service.getPartitionMap(dbConfig)
+			return err
+		}},
+		// 全库字段类型详情（按表聚合）
+		{"columnTypeDetail", func(service *DbDictService) (err error) {
+			columnTypeDetailMap, err = service.getColumnTypeDetailMap(dbConfig)
+			return err
+		}},
+		// 全库自定义类型
+		{"type", func(service *DbDictService) (err error) {
+			typeList, err = service.getTypeList(dbConfig)
+			return err
+		}},
+		// 库默认字符集及排序规则
+		{"databaseCollation", func(service *DbDictService) (err error) {
+			databaseCollation, err = service.getDatabaseCollation(dbConfig)
+			return err
+		}},
+		// 全库表排序规则（按表聚合）
+		{"tableCollation", func(service *DbDictService) (err error) {
+			tableCollationMap, err = service.getTableCollationMap(dbConfig)
+			return err
+		}},
+		// 全库字段排序规则（按表聚合）
+		{"columnCollation", func(service *DbDictService) (err error) {
+			columnCollationMap, err = service.getColumnCollationMap(dbConfig)
+			return err
+		}},
+		// 全库方言标注（按表聚合）
+		{"annotation", func(service *DbDictService) (err error) {
+			annotationMap, err = service.getAnnotationMap(dbConfig)
+			return err
+		}},
+		// 全库表引擎（按表聚合）
+		{"tableEngine", func(service *DbDictService) (err error) {
+			tableEngineMap, err = service.getTableEngineMap(dbConfig)
+			return err
+		}},
 	}
-	// 获取全库字段注释（按表聚合）
-	tableCommentMap, err := this.getTableComment(dbConfig)
-	if err != nil {
+	startedAt := time.Now()
+	err := this.runMetadataQueryList(ctx, queryList)
+	slog.Info("读取元数据", "queryCount", len(queryList), "elapsed", time.Since(startedAt))
+	if nil != err {
+		// 已取消时只返回取消原因
+		if nil != ctx.Err() {
+			return nil, ctx.Err()
+		}
 		return nil, err
 	}
-	// 获取全库字段类型（按表聚合）
-	tableColumnInfoMap, err := this.getTableColumnInfoMap(dbConfig)
-	if err != nil {
-		return nil, err
+
+	// PostgresSQL 的子分区本身也是表，需要折叠到父表下
+	partitionTableNameMap := map[string]bool{}
+	if "postgres" == this.DB.Dialector.Name() {
+		for _, partitionList := range partitionMap {
+			for _, partitionInfo := range partitionList {
+				partitionTableNameMap[partitionInfo.PartitionName] = true
+			}
+		}
 	}
 
 	// 获取所有表名
@@ -109,16 +439,25 @@ func (this *DbDictService) GetDatabaseInfo(dbConfig *configs.DatabaseConfig, sel
 	}
 	// 排序
 	sort.Strings(tableList)
-	if err != nil {
-		return nil, err
-	}
 
 	// 结果
 	tableNameList := []string{}
 	tableMap := make(map[string]models.TableInfo)
+	startedAt = time.Now()
 	// 遍历表
-	for _, tableName := range tableList {
-		tableInfo, err := this.buildTableInfo(databaseName, tableName, &tableTypeMap, &tableColumnInfoMap, &indexInfoListMap, &tableCommentMap)
+	for i, tableName := range tableList {
+		// 已取消
+		if err := ctx.Err(); nil != err {
+			return nil, err
+		}
+		progress.report(ProgressPhaseMetadata, i+1, len(tableList), tableName)
+
+		// 跳过子分区
+		if partitionTableNameMap[tableName] {
+			continue
+		}
+
+		tableInfo, err := this.buildTableInfo(databaseName, tableName, &tableTypeMap, &tableColumnInfoMap, &indexInfoListMap, &tableCommentMap, &viewDefinitionMap, &checkConstraintMap, &generatedColumnMap, sequenceList, &partitionMap, &columnTypeDetailMap, typeList, &tableCollationMap, &columnCollationMap, &annotationMap, &tableEngineMap)
 		if err != nil {
 			continue
 		}
@@ -129,34 +468,62 @@ func (this *DbDictService) GetDatabaseInfo(dbConfig *configs.DatabaseConfig, sel
 	}
 	// 排序
 	sort.Strings(tableNameList)
+	slog.Info("整理表信息", "tableCount", len(tableNameList), "elapsed", time.Since(startedAt))
 
 	// 生成数据库信息
 	dbInfo := models.NewDatabaseInfo(databaseName, tableMap, selectedTableNameList)
+	// 全库序列
+	dbInfo.SequenceList = sequenceList
+	// 全库自定义类型
+	dbInfo.TypeList = typeList
+	// 库默认字符集及排序规则
+	if nil != databaseCollation {
+		dbInfo.CharacterSetName = databaseCollation.CharacterSetName
+		dbInfo.CollationName = databaseCollation.CollationName
+	}
 
 	return dbInfo, nil
 }
 
 //  ----- BUILD --------------------
 
-// BuildAll 生成数据库
-func (this *DbDictService) BuildAll(dbConfig *configs.DatabaseConfig, outputDirPath string, format string, overwrite bool, selectedTableNameList []string) (result []string, err error) {
+// BuildAll 生成数据库，ctx 取消时删除已生成的文件并返回 ctx.Err()
+func (this *DbDictService) BuildAll(ctx context.Context, dbConfig *configs.DatabaseConfig, outputDirPath string, format string, overwrite bool, selectedTableNameList []string, progress ProgressFunc) (result []string, err error) {
 	/* 获取数据库信息 */
-	databaseInfo, err := this.GetDatabaseInfo(dbConfig, selectedTableNameList)
+	databaseInfo, err := this.GetDatabaseInfo(ctx, dbConfig, selectedTableNameList, progress)
 	if nil != err {
 		slog.Error("生成数据库字典失败", "error", err)
 		return nil, err
 	}
 	slog.Debug("数据库字典", "databaseInfo", databaseInfo)
 
+	// 取消时删除不完整的文件
+	defer func() {
+		if nil != err && nil != ctx.Err() {
+			removeFileList(result)
+			result = nil
+		}
+	}()
+
 	/* 准备生成数据 */
 	// 选中的数据表数量（包含索引页）
 	total := databaseInfo.GetSelectedTableCount() + 1
 	// 当前数量
 	current := 0
+	// 生成耗时
+	startedAt := time.Now()
+	defer func() {
+		slog.Info("生成文档", "format", format, "count", current, "elapsed", time.Since(startedAt))
+	}()
 
 	/* 生成数据库信息 */
+	// 已取消
+	if err = ctx.Err(); nil != err {
+		return nil, err
+	}
 	// 计数
 	current++
+	progress.report(ProgressPhaseRendering, current, total, databaseInfo.DatabaseName)
 	cur, err := this.rendering(dbConfig, format, databaseInfo, outputDirPath, overwrite, total, current)
 	if nil != err {
 		return nil, err
@@ -171,8 +538,14 @@ func (this *DbDictService) BuildAll(dbConfig *configs.DatabaseConfig, outputDirP
 	tableInfoMap := databaseInfo.TableMap
 	// 遍历保存
 	for _, tableName := range databaseInfo.GetSelectedTableNameList() {
+		// 已取消
+		if err = ctx.Err(); nil != err {
+			return result, err
+		}
+
 		// 计数
 		current++
+		progress.report(ProgressPhaseRendering, current, total, tableName)
 		// 当前表信息
 		tableInfo := tableInfoMap[tableName]
 		// 保存到md文件
@@ -189,3 +562,12 @@ func (this *DbDictService) BuildAll(dbConfig *configs.DatabaseConfig, outputDirP
 
 	return result, nil
 }
+
+// removeFileList 删除文件，忽略不存在的文件
+func removeFileList(pathList []string) {
+	for _, path := range pathList {
+		if err := os.Remove(path); nil != err && !os.IsNotExist(err) {
+			slog.Warn("删除文件失败", "path", path, "error", err)
+		}
+	}
+}
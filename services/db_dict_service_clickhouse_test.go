@@ -0,0 +1,316 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/mattn/go-sqlite3"
+	"goDict/configs"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestParseClickHouseTTL(t *testing.T) {
+	testList := []struct {
+		name       string
+		engineFull string
+		want       string
+	}{
+		{
+			name:       "TTL 后有 SETTINGS",
+			engineFull: "MergeTree PARTITION BY toYYYYMM(d) ORDER BY id TTL d + toIntervalDay(30) SETTINGS index_granularity = 8192",
+			want:       "d + toIntervalDay(30)",
+		},
+		{
+			name:       "TTL 后没有 SETTINGS",
+			engineFull: "ReplacingMergeTree(version) ORDER BY id TTL d + toIntervalMonth(1) DELETE",
+			want:       "d + toIntervalMonth(1) DELETE",
+		},
+		{
+			name: "多行引擎定义及多条 TTL 规则",
+			engineFull: "MergeTree\nPARTITION BY toYYYYMM(d)\nORDER BY (id, d)\n" +
+				"TTL d + toIntervalDay(7) TO VOLUME 'cold',\n    d + toIntervalDay(30) DELETE\n" +
+				"SETTINGS index_granularity = 8192, storage_policy = 'tiered'",
+			want: "d + toIntervalDay(7) TO VOLUME 'cold',\n    d + toIntervalDay(30) DELETE",
+		},
+		{
+			name:       "没有 TTL",
+			engineFull: "MergeTree ORDER BY ttl_days SETTINGS index_granularity = 8192",
+			want:       "",
+		},
+		{
+			name:       "视图没有引擎定义",
+			engineFull: "",
+			want:       "",
+		},
+	}
+	for _, test := range testList {
+		t.Run(test.name, func(t *testing.T) {
+			if got := parseClickHouseTTL(test.engineFull); test.want != got {
+				t.Errorf("parseClickHouseTTL() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+// clickHouseReplayDialector 在 SQLite 中回放 ClickHouse 系统表，Name 为 clickhouse 以执行 ClickHouse 的查询语句
+type clickHouseReplayDialector struct {
+	*sqlite.Dialector
+}
+
+func (this clickHouseReplayDialector) Name() string {
+	return "clickhouse"
+}
+
+// ClickHouse 查询中在 SQLite 里是关键字的标识符，回放前加双引号
+var clickHouseReplayKeywordRegexp = regexp.MustCompile(`\.table\b|\bAS default\b`)
+
+// clickHouseReplayConnPool 执行前将 c.table、AS default 改为 c."table"、AS "default"
+type clickHouseReplayConnPool struct {
+	*sql.DB
+}
+
+func (this clickHouseReplayConnPool) quote(query string) string {
+	return clickHouseReplayKeywordRegexp.ReplaceAllStringFunc(query, func(keyword string) string {
+		if strings.HasPrefix(keyword, ".") {
+			return `."table"`
+		}
+		return `AS "default"`
+	})
+}
+
+func (this clickHouseReplayConnPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return this.DB.PrepareContext(ctx, this.quote(query))
+}
+
+func (this clickHouseReplayConnPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return this.DB.ExecContext(ctx, this.quote(query), args...)
+}
+
+func (this clickHouseReplayConnPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return this.DB.QueryContext(ctx, this.quote(query), args...)
+}
+
+func (this clickHouseReplayConnPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return this.DB.QueryRowContext(ctx, this.quote(query), args...)
+}
+
+// registerClickHouseReplayDriver 注册 SQLite 驱动，附加 system 库并补充查询用到的 ClickHouse 函数
+var registerClickHouseReplayDriver = sync.OnceFunc(func() {
+	sql.Register("sqlite3_clickhouse", &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			if err := conn.RegisterFunc("startsWith", strings.HasPrefix, true); nil != err {
+				return err
+			}
+			if err := conn.RegisterFunc("toString", func(value interface{}) string { return fmt.Sprint(value) }, true); nil != err {
+				return err
+			}
+			_, err := conn.Exec("ATTACH DATABASE ':memory:' AS system", nil)
+			return err
+		},
+	})
+})
+
+// openClickHouseFixture 将 testdata/clickhouse 中的系统表数据（FORMAT JSONEachRow 格式）导入 SQLite
+func openClickHouseFixture(t *testing.T) *DbDictService {
+	t.Helper()
+	registerClickHouseReplayDriver()
+	sqlDB, err := sql.Open("sqlite3_clickhouse", ":memory:")
+	if nil != err {
+		t.Fatal(err)
+	}
+	// 内存库每个连接独立，固定使用一个连接
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(clickHouseReplayDialector{&sqlite.Dialector{Conn: clickHouseReplayConnPool{sqlDB}}},
+		&gorm.Config{Logger: logger.Discard})
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	for _, tableName := range []string{"columns", "tables", "data_skipping_indices"} {
+		loadClickHouseFixture(t, db, tableName)
+	}
+	return &DbDictService{DB: db}
+}
+
+// loadClickHouseFixture 按首行的字段建表并导入 system.<tableName>
+func loadClickHouseFixture(t *testing.T, db *gorm.DB, tableName string) {
+	t.Helper()
+	file, err := os.Open(filepath.Join("testdata", "clickhouse", "system_"+tableName+".jsonl"))
+	if nil != err {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	var columnNameList []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		row := map[string]interface{}{}
+		decoder := json.NewDecoder(strings.NewReader(scanner.Text()))
+		decoder.UseNumber()
+		if err = decoder.Decode(&row); nil != err {
+			t.Fatal(err)
+		}
+		if nil == columnNameList {
+			for columnName := range row {
+				columnNameList = append(columnNameList, columnName)
+			}
+			slices.Sort(columnNameList)
+			quotedList := []string{}
+			for _, columnName := range columnNameList {
+				quotedList = append(quotedList, `"`+columnName+`"`)
+			}
+			if err = db.Exec(fmt.Sprintf("CREATE TABLE system.%s (%s)", tableName, strings.Join(quotedList, ", "))).Error; nil != err {
+				t.Fatal(err)
+			}
+		}
+		// 64 位整数按 ClickHouse 默认输出为字符串，导入后由 SQL 比较及扫描时转换
+		valueList := []interface{}{}
+		for _, columnName := range columnNameList {
+			value := row[columnName]
+			if number, ok := value.(json.Number); ok {
+				value, _ = number.Int64()
+			}
+			valueList = append(valueList, value)
+		}
+		placeholder := strings.TrimSuffix(strings.Repeat("?, ", len(columnNameList)), ", ")
+		if err = db.Exec(fmt.Sprintf("INSERT INTO system.%s VALUES (%s)", tableName, placeholder), valueList...).Error; nil != err {
+			t.Fatal(err)
+		}
+	}
+	if err = scanner.Err(); nil != err {
+		t.Fatal(err)
+	}
+}
+
+func TestClickHouseMetadata(t *testing.T) {
+	service := openClickHouseFixture(t)
+	dbConfig := &configs.DatabaseConfig{Type: "ClickHouse", Database: "shop"}
+
+	// 列：Nullable(...) 为可空，is_in_primary_key 为主键（排序键中不属于主键的列不是主键）
+	columnInfoMap, err := service.getTableColumnInfoMap(dbConfig)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if 2 != len(columnInfoMap) {
+		t.Errorf("tables = %d, want 2 (other databases excluded)", len(columnInfoMap))
+	}
+	type columnWant struct {
+		name      string
+		nullable  bool
+		isPrimary bool
+	}
+	wantList := []columnWant{
+		{"id", false, true},
+		{"event_date", false, false},
+		{"country", false, false},
+		{"user_name", true, false},
+		{"payload", false, false},
+		{"amount", false, false},
+	}
+	columnInfoList := columnInfoMap["events"]
+	if len(wantList) != len(columnInfoList) {
+		t.Fatalf("events columns = %d, want %d", len(columnInfoList), len(wantList))
+	}
+	for i, want := range wantList {
+		columnInfo := columnInfoList[i]
+		if want.name != columnInfo.ColumnName || want.nullable != columnInfo.Nullable || want.isPrimary != columnInfo.IsPrimary {
+			t.Errorf("column %d = {%s nullable:%v primary:%v}, want %+v",
+				i, columnInfo.ColumnName, columnInfo.Nullable, columnInfo.IsPrimary, want)
+		}
+	}
+	if country := columnInfoList[2]; 2 != country.Length {
+		t.Errorf("country length = %d, want 2", country.Length)
+	}
+	if userName := columnInfoList[3]; "用户名" != userName.Comment {
+		t.Errorf("user_name comment = %q, want 用户名", userName.Comment)
+	}
+	if payload := columnInfoList[4]; "''" != payload.Default || "CODEC(ZSTD(1))" != payload.Codec {
+		t.Errorf("payload default = %q, codec = %q, want '' and CODEC(ZSTD(1))", payload.Default, payload.Codec)
+	}
+	if amount := columnInfoList[5]; 10 != amount.Precision || 2 != amount.Scale {
+		t.Errorf("amount precision = %d, scale = %d, want 10 and 2", amount.Precision, amount.Scale)
+	}
+	for _, columnInfo := range columnInfoMap["events_view"] {
+		if columnInfo.IsPrimary {
+			t.Errorf("view column %s is primary", columnInfo.ColumnName)
+		}
+	}
+
+	// 索引：跳数索引及 system.tables 中的主键
+	indexInfoMap, err := service.getTableIndexInfoMap(dbConfig)
+	if nil != err {
+		t.Fatal(err)
+	}
+	indexList := indexInfoMap["events"]
+	if 2 != len(indexList) || 1 != len(indexInfoMap) {
+		t.Fatalf("index map = %v, want 2 indexes on events only", indexInfoMap)
+	}
+	if primary := indexList[0]; "PRIMARY" != primary.IndexName || !primary.IsPrimary || "id" != primary.ColumnNames {
+		t.Errorf("primary = %+v, want PRIMARY on id", primary)
+	}
+	if skipping := indexList[1]; "idx_user" != skipping.IndexName || skipping.IsPrimary ||
+		"bloom_filter" != skipping.IndexType || "user_name" != skipping.ColumnNames || "GRANULARITY 4" != skipping.IndexComment {
+		t.Errorf("skipping index = %+v, want idx_user bloom_filter on user_name GRANULARITY 4", skipping)
+	}
+
+	// 表类型及注释
+	tableTypeMap, err := service.getTableType(dbConfig)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if 2 != len(tableTypeMap) || "table" != tableTypeMap["events"] || "view" != tableTypeMap["events_view"] {
+		t.Errorf("table type map = %v, want map[events:table events_view:view]", tableTypeMap)
+	}
+	tableCommentMap, err := service.getTableComment(dbConfig)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if "事件" != tableCommentMap["events"] {
+		t.Errorf("table comment = %q, want 事件", tableCommentMap["events"])
+	}
+
+	// 引擎及 TTL
+	tableEngineMap, err := service.getTableEngineMap(dbConfig)
+	if nil != err {
+		t.Fatal(err)
+	}
+	engine := tableEngineMap["events"]
+	if nil == engine || "MergeTree" != engine.Engine || "id, event_date" != engine.SortingKey || "id" != engine.PrimaryKey {
+		t.Fatalf("engine = %+v, want MergeTree sorting key (id, event_date) primary key id", engine)
+	}
+	if ttl := parseClickHouseTTL(engine.EngineFull); "event_date + toIntervalDay(90)" != ttl {
+		t.Errorf("ttl = %q, want event_date + toIntervalDay(90)", ttl)
+	}
+}
+
+func TestOptionalMetadataError(t *testing.T) {
+	service := openClickHouseFixture(t)
+	dbConfig := &configs.DatabaseConfig{Type: "ClickHouse", Database: "shop"}
+
+	// 查询失败（回放数据中没有 system.parts）时忽略
+	partitionMap, err := service.getPartitionMap(dbConfig)
+	if nil != err || 0 != len(partitionMap) {
+		t.Errorf("partition map = %v, err = %v, want empty without error", partitionMap, err)
+	}
+
+	// 取消时返回错误
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	canceledService := &DbDictService{DB: service.DB.WithContext(ctx)}
+	if _, err = canceledService.getTableEngineMap(dbConfig); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
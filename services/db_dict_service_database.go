@@ -1,11 +1,19 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"goDict/configs"
 	"goDict/models"
+	"log/slog"
+	"regexp"
+	"strings"
 )
 
+// ClickHouse 引擎定义中的 TTL 子句（位于 SETTINGS 之前）
+var clickHouseTTLRegexp = regexp.MustCompile(`(?s)\sTTL\s+(.+?)(?:\sSETTINGS\s.*)?$`)
+
 // TableComment 表注释信息
 type TableComment struct {
 	TableName string `json:"table_name"`
@@ -81,6 +89,9 @@ func (this *DbDictService) getTableIndexInfoMap(dbConfig *configs.DatabaseConfig
 	// Sqlite 不需要传数据库名
 	if "sqlite" == dbType {
 		params = []interface{}{}
+	} else if "duckdb" == dbType || "clickhouse" == dbType {
+		// DuckDB、ClickHouse 索引与主键合并查询，需要传两次
+		params = []interface{}{dbConfig.Database, dbConfig.Database}
 	}
 	// 调用
 	err := this.DB.Raw(query, params...).Scan(&dataList).Error
@@ -114,7 +125,7 @@ func (this *DbDictService) getTableIndexInfoMap(dbConfig *configs.DatabaseConfig
 func (this *DbDictService) getTableComment(dbConfig *configs.DatabaseConfig) (map[string]string, error) {
 	var tableComments []TableComment
 
-	dbType := this.DB.Dialector.Name()
+	dbType := this.getBaseDialectName()
 
 	switch dbType {
 	case "sqlserver":
@@ -147,7 +158,7 @@ func (this *DbDictService) getTableComment(dbConfig *configs.DatabaseConfig) (ma
 			  , TABLE_COMMENT AS comment
 			FROM INFORMATION_SCHEMA.TABLES
 			WHERE
-				  TABLE_TYPE IN ('VIEW', 'BASE TABLE')
+				  TABLE_TYPE IN ('VIEW', 'BASE TABLE', 'SYSTEM VERSIONED')
 			  AND TABLE_SCHEMA = ? 
         `
 		// MySQL需要提供databaseName
@@ -191,6 +202,50 @@ func (this *DbDictService) getTableComment(dbConfig *configs.DatabaseConfig) (ma
 		// Oracle需要提供databaseName
 		params := []interface{}{dbConfig.Database}
 
+		// 执行
+		err := this.DB.Raw(query, params...).Scan(&tableComments).Error
+		if err != nil {
+			return nil, err
+		}
+	case "duckdb":
+		// DuckDB 从 duckdb_tables()、duckdb_views() 获取表注释
+		query := `
+			SELECT
+				t.table_name AS table_name
+			  , t.comment AS comment
+			FROM duckdb_tables() t
+			WHERE
+				t.database_name = ?
+			UNION ALL
+			SELECT
+				v.view_name AS table_name
+			  , v.comment AS comment
+			FROM duckdb_views() v
+			WHERE
+				  NOT v.internal
+			  AND v.database_name = ?
+        `
+		// DuckDB需要提供databaseName
+		params := []interface{}{dbConfig.Database, dbConfig.Database}
+
+		// 执行
+		err := this.DB.Raw(query, params...).Scan(&tableComments).Error
+		if err != nil {
+			return nil, err
+		}
+	case "clickhouse":
+		// ClickHouse 从 system.tables 获取表注释
+		query := `
+			SELECT
+				t.name AS table_name
+			  , t.comment AS comment
+			FROM system.tables t
+			WHERE
+				t.database = ?
+        `
+		// ClickHouse需要提供databaseName
+		params := []interface{}{dbConfig.Database}
+
 		// 执行
 		err := this.DB.Raw(query, params...).Scan(&tableComments).Error
 		if err != nil {
@@ -217,7 +272,7 @@ func (this *DbDictService) getTableComment(dbConfig *configs.DatabaseConfig) (ma
 func (this *DbDictService) getTableColumnComment(tableName string) (map[string]string, error) {
 	var columnComments []ColumnComment
 
-	dbType := this.DB.Dialector.Name()
+	dbType := this.getBaseDialectName()
 
 	switch dbType {
 	case "sqlserver":
@@ -333,3 +388,520 @@ func (this *DbDictService) getTableType(dbConfig *configs.DatabaseConfig) (map[s
 
 	return result, nil
 }
+
+// getViewDefinitionMap 获取视图定义（按视图名聚合）
+func (this *DbDictService) getViewDefinitionMap(dbConfig *configs.DatabaseConfig) (map[string]string, error) {
+	var dataList []*models.ViewDefinition
+
+	// 类型
+	dbType := this.DB.Dialector.Name()
+	// SQL
+	query, ok := sql_getViewDefinitionMap[dbType]
+	if !ok {
+		return map[string]string{}, nil
+	}
+	// 参数
+	var params []interface{}
+	// Sqlite不需要传递参数，其他都需要传递
+	if "sqlite" != dbType {
+		params = append(params, dbConfig.Database)
+	}
+	// 执行
+	err := this.DB.Raw(query, params...).Scan(&dataList).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// 转换为map
+	result := make(map[string]string)
+	for _, item := range dataList {
+		result[item.TableName] = item.ViewDefinition
+	}
+
+	return result, nil
+}
+
+// getCheckConstraintMap 获取检查约束（按表聚合）
+func (this *DbDictService) getCheckConstraintMap(dbConfig *configs.DatabaseConfig) (map[string][]*models.CheckConstraintInfo, error) {
+	var dataList []*models.CheckConstraintInfo
+
+	// 结果
+	result := make(map[string][]*models.CheckConstraintInfo)
+
+	// 类型
+	dbType := this.DB.Dialector.Name()
+	// SQL
+	query, ok := sql_getCheckConstraintMap[dbType]
+	if !ok {
+		return result, nil
+	}
+	// 参数
+	var params []interface{}
+	// Sqlite不需要传递参数，其他都需要传递
+	if "sqlite" != dbType {
+		params = append(params, dbConfig.Database)
+	}
+	// 执行（旧版本数据库可能不支持，忽略错误）
+	err := this.DB.Raw(query, params...).Scan(&dataList).Error
+	if err != nil {
+		return result, this.ignoreOptionalError("获取检查约束失败", err)
+	}
+
+	// 将数据根据tableName聚合
+	for _, item := range dataList {
+		// SQLite 从建表语句中解析
+		if "sqlite" == dbType {
+			result[item.TableName] = append(result[item.TableName], parseSqliteCheckConstraintList(item.DatabaseName, item.TableName, item.CheckClause)...)
+			continue
+		}
+		result[item.TableName] = append(result[item.TableName], item)
+	}
+
+	return result, nil
+}
+
+// getGeneratedColumnMap 获取生成列（按表、字段聚合）
+func (this *DbDictService) getGeneratedColumnMap(dbConfig *configs.DatabaseConfig) (map[string]map[string]*models.GeneratedColumnInfo, error) {
+	var dataList []*models.GeneratedColumnInfo
+
+	// 结果
+	result := make(map[string]map[string]*models.GeneratedColumnInfo)
+
+	// 类型
+	dbType := this.DB.Dialector.Name()
+	// SQL
+	query, ok := sql_getGeneratedColumnMap[dbType]
+	if !ok {
+		return result, nil
+	}
+	// 参数
+	var params []interface{}
+	// Sqlite不需要传递参数，其他都需要传递
+	if "sqlite" != dbType {
+		params = append(params, dbConfig.Database)
+	}
+	// 执行（旧版本数据库可能不支持，忽略错误）
+	err := this.DB.Raw(query, params...).Scan(&dataList).Error
+	if err != nil {
+		return result, this.ignoreOptionalError("获取生成列失败", err)
+	}
+
+	// 将数据根据tableName聚合
+	for _, item := range dataList {
+		// SQLite、DuckDB 从建表语句中解析
+		if "sqlite" == dbType || "duckdb" == dbType {
+			item.GenerationExpression = parseSqliteGenerationExpression(item.GenerationExpression, item.ColumnName)
+		}
+		// DuckDB 中普通默认值也会被查出，没有解析到表达式的不是生成列
+		if "duckdb" == dbType && "" == item.GenerationExpression {
+			continue
+		}
+		if _, ok := result[item.TableName]; !ok {
+			result[item.TableName] = make(map[string]*models.GeneratedColumnInfo)
+		}
+		result[item.TableName][item.ColumnName] = item
+	}
+
+	return result, nil
+}
+
+// getSequenceList 获取全库序列
+func (this *DbDictService) getSequenceList(dbConfig *configs.DatabaseConfig) ([]*models.SequenceInfo, error) {
+	dataList := []*models.SequenceInfo{}
+
+	// 类型
+	dbType := this.DB.Dialector.Name()
+	// SQL（MySQL、SQLite 不支持序列）
+	query, ok := sql_getSequenceMap[dbType]
+	if !ok {
+		return dataList, nil
+	}
+	// 参数
+	params := []interface{}{dbConfig.Database}
+	// 执行（旧版本数据库可能不支持，忽略错误）
+	err := this.DB.Raw(query, params...).Scan(&dataList).Error
+	if err != nil {
+		return []*models.SequenceInfo{}, this.ignoreOptionalError("获取序列失败", err)
+	}
+
+	return dataList, nil
+}
+
+// parseSqliteCheckConstraintList 从 SQLite 建表语句中解析检查约束
+func parseSqliteCheckConstraintList(databaseName string, tableName string, createSql string) []*models.CheckConstraintInfo {
+	result := []*models.CheckConstraintInfo{}
+
+	// 词法分析
+	tokenList := tokenizeSql(createSql)
+	for idx := 0; idx+1 < len(tokenList); idx++ {
+		token := tokenList[idx]
+		if !token.isSimpleIdent() || !strings.EqualFold("CHECK", token.parts[0]) || !tokenList[idx+1].isSymbol("(") {
+			continue
+		}
+
+		// 约束名：CONSTRAINT name CHECK (...)
+		constraintName := ""
+		if 2 <= idx && tokenList[idx-1].isSimpleIdent() && tokenList[idx-2].isSimpleIdent() && strings.EqualFold("CONSTRAINT", tokenList[idx-2].parts[0]) {
+			constraintName = tokenList[idx-1].parts[0]
+		}
+
+		// 找到匹配的右括号
+		depth := 0
+		end := idx + 1
+		for ; end < len(tokenList); end++ {
+			if tokenList[end].isSymbol("(") {
+				depth++
+			} else if tokenList[end].isSymbol(")") {
+				depth--
+				if 0 == depth {
+					break
+				}
+			}
+		}
+		if end >= len(tokenList) {
+			break
+		}
+
+		result = append(result, &models.CheckConstraintInfo{
+			DatabaseName:   databaseName,
+			TableName:      tableName,
+			ConstraintName: constraintName,
+			CheckClause:    joinSqlTokenList(tokenList[idx+2 : end]),
+		})
+		idx = end
+	}
+
+	return result
+}
+
+// parseSqliteGenerationExpression 从 SQLite 建表语句中解析生成列表达式：col type [GENERATED ALWAYS] AS (expr)
+func parseSqliteGenerationExpression(createSql string, columnName string) string {
+	// 词法分析
+	tokenList := tokenizeSql(createSql)
+
+	depth := 0
+	for idx := 0; idx < len(tokenList); idx++ {
+		token := tokenList[idx]
+		if token.isSymbol("(") {
+			depth++
+			continue
+		} else if token.isSymbol(")") {
+			depth--
+			continue
+		}
+		// 字段定义位于建表语句的第一层括号内，且位于行首（逗号或左括号之后）
+		if 1 != depth || !token.isSimpleIdent() || !strings.EqualFold(columnName, token.parts[0]) {
+			continue
+		}
+		if !tokenList[idx-1].isSymbol("(") && !tokenList[idx-1].isSymbol(",") {
+			continue
+		}
+
+		// 在当前字段定义内查找 AS (
+		for cur := idx + 1; cur+1 < len(tokenList); cur++ {
+			if tokenList[cur].isSymbol(",") || tokenList[cur].isSymbol(")") {
+				break
+			}
+			if tokenList[cur].isSymbol("(") && !(tokenList[cur-1].isKeyword("AS")) {
+				// 跳过类型长度等括号内容，如 VARCHAR(20)
+				for inner := 0; cur < len(tokenList); cur++ {
+					if tokenList[cur].isSymbol("(") {
+						inner++
+					} else if tokenList[cur].isSymbol(")") {
+						inner--
+						if 0 == inner {
+							break
+						}
+					}
+				}
+				continue
+			}
+			if !tokenList[cur].isKeyword("AS") || !tokenList[cur+1].isSymbol("(") {
+				continue
+			}
+
+			// 找到匹配的右括号
+			inner := 0
+			for end := cur + 1; end < len(tokenList); end++ {
+				if tokenList[end].isSymbol("(") {
+					inner++
+				} else if tokenList[end].isSymbol(")") {
+					inner--
+					if 0 == inner {
+						return joinSqlTokenList(tokenList[cur+2 : end])
+					}
+				}
+			}
+			return ""
+		}
+		return ""
+	}
+
+	return ""
+}
+
+// getPartitionMap 获取分区信息（按父表聚合）
+func (this *DbDictService) getPartitionMap(dbConfig *configs.DatabaseConfig) (map[string][]*models.PartitionInfo, error) {
+	var dataList []*models.PartitionInfo
+
+	// 结果
+	result := make(map[string][]*models.PartitionInfo)
+
+	// 类型
+	dbType := this.DB.Dialector.Name()
+	// SQL（SQLite 不支持分区）
+	query, ok := sql_getPartitionMap[dbType]
+	if !ok {
+		return result, nil
+	}
+	// 参数
+	params := []interface{}{dbConfig.Database}
+	// 执行（旧版本数据库可能不支持，忽略错误）
+	err := this.DB.Raw(query, params...).Scan(&dataList).Error
+	if err != nil {
+		return result, this.ignoreOptionalError("获取分区信息失败", err)
+	}
+
+	// 将数据根据tableName聚合
+	for _, item := range dataList {
+		result[item.TableName] = append(result[item.TableName], item)
+	}
+
+	return result, nil
+}
+
+// getColumnTypeDetailMap 获取字段类型详情（按表、字段聚合）
+func (this *DbDictService) getColumnTypeDetailMap(dbConfig *configs.DatabaseConfig) (map[string]map[string]*models.ColumnTypeDetail, error) {
+	var dataList []*models.ColumnTypeDetail
+
+	// 结果
+	result := make(map[string]map[string]*models.ColumnTypeDetail)
+
+	// 类型
+	dbType := this.DB.Dialector.Name()
+	// SQL（Oracle、SQLite 暂不支持）
+	query, ok := sql_getColumnTypeDetailMap[dbType]
+	if !ok {
+		return result, nil
+	}
+	// 参数
+	params := []interface{}{dbConfig.Database}
+	// 执行（旧版本数据库可能不支持，忽略错误）
+	err := this.DB.Raw(query, params...).Scan(&dataList).Error
+	if err != nil {
+		return result, this.ignoreOptionalError("获取字段类型详情失败", err)
+	}
+
+	// 将数据根据tableName聚合
+	for _, item := range dataList {
+		if _, ok := result[item.TableName]; !ok {
+			result[item.TableName] = make(map[string]*models.ColumnTypeDetail)
+		}
+		result[item.TableName][item.ColumnName] = item
+	}
+
+	return result, nil
+}
+
+// getTypeList 获取全库自定义类型
+func (this *DbDictService) getTypeList(dbConfig *configs.DatabaseConfig) ([]*models.TypeInfo, error) {
+	dataList := []*models.TypeInfo{}
+
+	// 类型
+	dbType := this.DB.Dialector.Name()
+	// SQL（仅 PostgresSQL、DuckDB）
+	query, ok := sql_getTypeMap[dbType]
+	if !ok {
+		return dataList, nil
+	}
+	// 参数
+	params := []interface{}{dbConfig.Database}
+	// 执行（旧版本数据库可能不支持，忽略错误）
+	err := this.DB.Raw(query, params...).Scan(&dataList).Error
+	if err != nil {
+		return []*models.TypeInfo{}, this.ignoreOptionalError("获取自定义类型失败", err)
+	}
+
+	return dataList, nil
+}
+
+// parseEnumValueList 解析 MySQL 枚举/集合定义，如 enum('a','b')
+func parseEnumValueList(columnType string) []string {
+	result := []string{}
+	for _, token := range tokenizeSql(columnType) {
+		if sqlTokenString == token.kind {
+			result = append(result, strings.TrimSuffix(strings.TrimPrefix(token.text, "'"), "'"))
+		}
+	}
+	return result
+}
+
+// getDatabaseCollation 获取库默认字符集及排序规则，不支持或忽略错误时返回空的结果（不为 nil）
+func (this *DbDictService) getDatabaseCollation(dbConfig *configs.DatabaseConfig) (*models.CollationInfo, error) {
+	result := &models.CollationInfo{}
+
+	// 类型
+	dbType := this.DB.Dialector.Name()
+	// SQL（Oracle、SQLite、DuckDB 暂不支持）
+	query, ok := sql_getDatabaseCollationMap[dbType]
+	if !ok {
+		return result, nil
+	}
+	// 参数
+	params := []interface{}{dbConfig.Database}
+	// 执行（权限不足时可能失败，忽略错误）
+	err := this.DB.Raw(query, params...).Scan(result).Error
+	if err != nil {
+		return &models.CollationInfo{}, this.ignoreOptionalError("获取库排序规则失败", err)
+	}
+
+	return result, nil
+}
+
+// getTableCollationMap 获取表字符集及排序规则（按表聚合）
+func (this *DbDictService) getTableCollationMap(dbConfig *configs.DatabaseConfig) (map[string]*models.CollationInfo, error) {
+	var dataList []*models.CollationInfo
+
+	// 结果
+	result := make(map[string]*models.CollationInfo)
+
+	// 类型
+	dbType := this.DB.Dialector.Name()
+	// SQL（仅 MySQL 有表级排序规则）
+	query, ok := sql_getTableCollationMap[dbType]
+	if !ok {
+		return result, nil
+	}
+	// 参数
+	params := []interface{}{dbConfig.Database}
+	// 执行（忽略错误，超时或取消除外）
+	err := this.DB.Raw(query, params...).Scan(&dataList).Error
+	if err != nil {
+		return result, this.ignoreOptionalError("获取表排序规则失败", err)
+	}
+
+	for _, item := range dataList {
+		result[item.TableName] = item
+	}
+
+	return result, nil
+}
+
+// getColumnCollationMap 获取字段字符集及排序规则（按表、字段聚合）
+func (this *DbDictService) getColumnCollationMap(dbConfig *configs.DatabaseConfig) (map[string]map[string]*models.CollationInfo, error) {
+	var dataList []*models.CollationInfo
+
+	// 结果
+	result := make(map[string]map[string]*models.CollationInfo)
+
+	// 类型
+	dbType := this.DB.Dialector.Name()
+	// SQL（Oracle、SQLite、DuckDB 暂不支持）
+	query, ok := sql_getColumnCollationMap[dbType]
+	if !ok {
+		return result, nil
+	}
+	// 参数
+	params := []interface{}{dbConfig.Database}
+	// 执行（忽略错误，超时或取消除外）
+	err := this.DB.Raw(query, params...).Scan(&dataList).Error
+	if err != nil {
+		return result, this.ignoreOptionalError("获取字段排序规则失败", err)
+	}
+
+	// 将数据根据tableName聚合
+	for _, item := range dataList {
+		if _, ok := result[item.TableName]; !ok {
+			result[item.TableName] = make(map[string]*models.CollationInfo)
+		}
+		result[item.TableName][item.ColumnName] = item
+	}
+
+	return result, nil
+}
+
+// getAnnotationMap 获取方言特有标注（按表聚合）
+func (this *DbDictService) getAnnotationMap(dbConfig *configs.DatabaseConfig) (map[string][]*models.AnnotationInfo, error) {
+	var dataList []*models.AnnotationInfo
+
+	// 结果
+	result := make(map[string][]*models.AnnotationInfo)
+
+	// 类型
+	dbType := this.DB.Dialector.Name()
+	// SQL（仅 MariaDB、TiDB）
+	query, ok := sql_getAnnotationMap[dbType]
+	if !ok {
+		return result, nil
+	}
+	// 参数（多段查询合并，每段都需要库名）
+	params := []interface{}{}
+	for idx := strings.Count(query, "?"); 0 < idx; idx-- {
+		params = append(params, dbConfig.Database)
+	}
+	// 执行（旧版本数据库可能不支持，忽略错误）
+	err := this.DB.Raw(query, params...).Scan(&dataList).Error
+	if err != nil {
+		return result, this.ignoreOptionalError("获取方言标注失败", err)
+	}
+
+	// 将数据根据tableName聚合
+	for _, item := range dataList {
+		result[item.TableName] = append(result[item.TableName], item)
+	}
+
+	return result, nil
+}
+
+// getTableEngineMap 获取表引擎信息（按表聚合）
+func (this *DbDictService) getTableEngineMap(dbConfig *configs.DatabaseConfig) (map[string]*models.TableEngineInfo, error) {
+	var dataList []*models.TableEngineInfo
+
+	// 结果
+	result := make(map[string]*models.TableEngineInfo)
+
+	// 类型
+	dbType := this.DB.Dialector.Name()
+	// SQL（仅 MySQL 系、ClickHouse）
+	query, ok := sql_getTableEngineMap[dbType]
+	if !ok {
+		return result, nil
+	}
+	// 参数
+	params := []interface{}{dbConfig.Database}
+	// 执行（忽略错误，超时或取消除外）
+	err := this.DB.Raw(query, params...).Scan(&dataList).Error
+	if err != nil {
+		return result, this.ignoreOptionalError("获取表引擎失败", err)
+	}
+
+	for _, item := range dataList {
+		result[item.TableName] = item
+	}
+
+	return result, nil
+}
+
+// ignoreOptionalError 可选的元数据查询失败时（旧版本数据库可能不支持）记录日志并忽略；
+// 超时或取消时返回错误，由 runMetadataQueryList 汇总，避免不完整的结果被当作成功
+func (this *DbDictService) ignoreOptionalError(message string, err error) error {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return err
+	}
+	// 部分驱动不包装上下文的错误，以上下文状态为准
+	if ctx := this.DB.Statement.Context; nil != ctx && nil != ctx.Err() {
+		return fmt.Errorf("%w: %w", ctx.Err(), err)
+	}
+	slog.Warn(message, "error", err)
+	return nil
+}
+
+// parseClickHouseTTL 从 ClickHouse 完整引擎定义中解析 TTL 表达式
+// 如 MergeTree PARTITION BY d ORDER BY id TTL d + toIntervalDay(30) SETTINGS index_granularity = 8192
+func parseClickHouseTTL(engineFull string) string {
+	matchList := clickHouseTTLRegexp.FindStringSubmatch(engineFull)
+	if 2 > len(matchList) {
+		return ""
+	}
+	return strings.TrimSpace(matchList[1])
+}
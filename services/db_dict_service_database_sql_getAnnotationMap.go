@@ -0,0 +1,85 @@
+package services
+
+var (
+	// 方言特有的表、字段标注（column_name 为空时为表标注）
+	sql_getAnnotationMap = map[string]string{
+		// MariaDB 系统版本表、JSON（以 longtext + json_valid 检查约束实现）
+		"mariadb": `
+			SELECT
+				t.TABLE_SCHEMA AS database_name
+			  , t.TABLE_NAME AS table_name
+			  , NULL AS column_name
+			  , 'SYSTEM VERSIONED' AS annotation
+			FROM INFORMATION_SCHEMA.TABLES t
+			WHERE
+				  t.TABLE_TYPE = 'SYSTEM VERSIONED'
+			  AND t.TABLE_SCHEMA = ?
+			UNION ALL
+			SELECT
+				c.TABLE_SCHEMA AS database_name
+			  , c.TABLE_NAME AS table_name
+			  , c.COLUMN_NAME AS column_name
+			  , 'JSON' AS annotation
+			FROM INFORMATION_SCHEMA.COLUMNS c
+				 JOIN INFORMATION_SCHEMA.CHECK_CONSTRAINTS cc
+				 ON c.TABLE_SCHEMA = cc.CONSTRAINT_SCHEMA
+					 AND c.TABLE_NAME = cc.TABLE_NAME
+					 AND cc.CHECK_CLAUSE = CONCAT('json_valid(` + "`" + `', c.COLUMN_NAME, '` + "`" + `)')
+			WHERE
+				  c.DATA_TYPE = 'longtext'
+			  AND c.TABLE_SCHEMA = ?
+			UNION ALL
+			SELECT
+				c.TABLE_SCHEMA AS database_name
+			  , c.TABLE_NAME AS table_name
+			  , c.COLUMN_NAME AS column_name
+			  , c.EXTRA AS annotation
+			FROM INFORMATION_SCHEMA.COLUMNS c
+			WHERE
+				  c.EXTRA IN ('ROW START', 'ROW END')
+			  AND c.TABLE_SCHEMA = ?
+			ORDER BY
+				table_name
+			  , column_name
+		`,
+		// TiDB 聚簇主键、AUTO_RANDOM（TIDB_ROW_ID_SHARDING_INFO 形如 PK_AUTO_RANDOM_BITS=5）
+		"tidb": `
+			SELECT
+				t.TABLE_SCHEMA AS database_name
+			  , t.TABLE_NAME AS table_name
+			  , NULL AS column_name
+			  , 'CLUSTERED' AS annotation
+			FROM INFORMATION_SCHEMA.TABLES t
+			WHERE
+				  t.TIDB_PK_TYPE = 'CLUSTERED'
+			  AND t.TABLE_SCHEMA = ?
+			UNION ALL
+			SELECT
+				t.TABLE_SCHEMA AS database_name
+			  , t.TABLE_NAME AS table_name
+			  , k.COLUMN_NAME AS column_name
+			  , CONCAT('AUTO_RANDOM(', SUBSTRING(t.TIDB_ROW_ID_SHARDING_INFO, 21), ')') AS annotation
+			FROM INFORMATION_SCHEMA.TABLES t
+				 JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE k
+				 ON t.TABLE_SCHEMA = k.TABLE_SCHEMA
+					 AND t.TABLE_NAME = k.TABLE_NAME
+					 AND k.CONSTRAINT_NAME = 'PRIMARY'
+			WHERE
+				  t.TIDB_ROW_ID_SHARDING_INFO LIKE 'PK_AUTO_RANDOM_BITS=%'
+			  AND t.TABLE_SCHEMA = ?
+			UNION ALL
+			SELECT
+				t.TABLE_SCHEMA AS database_name
+			  , t.TABLE_NAME AS table_name
+			  , NULL AS column_name
+			  , CONCAT('SHARD_ROW_ID_BITS(', SUBSTRING(t.TIDB_ROW_ID_SHARDING_INFO, 12), ')') AS annotation
+			FROM INFORMATION_SCHEMA.TABLES t
+			WHERE
+				  t.TIDB_ROW_ID_SHARDING_INFO LIKE 'SHARD_BITS=%'
+			  AND t.TABLE_SCHEMA = ?
+			ORDER BY
+				table_name
+			  , column_name
+		`,
+	}
+)
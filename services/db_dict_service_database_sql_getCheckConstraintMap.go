@@ -0,0 +1,120 @@
+package services
+
+var (
+	sql_getCheckConstraintMap = map[string]string{
+		// SQL Server 查询检查约束
+		"sqlserver": `
+			SELECT
+				DB_NAME() AS database_name
+			  , t.name AS table_name
+			  , cc.name AS constraint_name
+			  , cc.definition AS check_clause
+			FROM sys.check_constraints cc
+				 JOIN sys.tables t
+				 ON cc.parent_object_id = t.object_id
+			WHERE
+				DB_NAME() = ?
+			ORDER BY
+				table_name
+			  , constraint_name
+		`,
+		// MySQL 查询检查约束（8.0.16 及以上版本）
+		"mysql": `
+			SELECT
+				tc.TABLE_SCHEMA AS database_name
+			  , tc.TABLE_NAME AS table_name
+			  , tc.CONSTRAINT_NAME AS constraint_name
+			  , cc.CHECK_CLAUSE AS check_clause
+			FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc
+				 JOIN INFORMATION_SCHEMA.CHECK_CONSTRAINTS cc
+				 ON tc.CONSTRAINT_SCHEMA = cc.CONSTRAINT_SCHEMA
+					 AND tc.CONSTRAINT_NAME = cc.CONSTRAINT_NAME
+			WHERE
+				  tc.CONSTRAINT_TYPE = 'CHECK'
+			  AND tc.TABLE_SCHEMA = ?
+			ORDER BY
+				table_name
+			  , constraint_name
+		`,
+		// PostgresSQL 查询检查约束
+		"postgres": `
+			SELECT
+				CURRENT_DATABASE() AS database_name
+			  , t.relname AS table_name
+			  , con.conname AS constraint_name
+			  , pg_get_constraintdef(con.oid) AS check_clause
+			FROM pg_constraint con
+				 JOIN pg_class t
+				 ON con.conrelid = t.oid
+				 JOIN pg_namespace n
+				 ON t.relnamespace = n.oid
+			WHERE
+				  con.contype = 'c'
+			  AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+			  AND current_database() = ?
+			ORDER BY
+				table_name
+			  , constraint_name
+		`,
+		// Oracle 查询检查约束（排除系统生成的 NOT NULL 约束）
+		"oracle": `
+			SELECT
+				SYS_CONTEXT('USERENV', 'DB_NAME') AS "database_name"
+			  , c.TABLE_NAME AS "table_name"
+			  , c.CONSTRAINT_NAME AS "constraint_name"
+			  , c.SEARCH_CONDITION_VC AS "check_clause"
+			FROM ALL_CONSTRAINTS c
+			WHERE
+				  c.CONSTRAINT_TYPE = 'C'
+			  AND c.SEARCH_CONDITION_VC NOT LIKE '% IS NOT NULL'
+			  AND c.OWNER = UPPER(?)
+			ORDER BY
+				"table_name"
+			  , "constraint_name"
+		`,
+		// SQLite 没有检查约束目录，返回建表语句后再解析
+		"sqlite": `
+			SELECT
+				'main' AS database_name
+			  , t.name AS table_name
+			  , NULL AS constraint_name
+			  , t.sql AS check_clause
+			FROM sqlite_master t
+			WHERE
+				  t.type = 'table'
+			  AND t.sql LIKE '%CHECK%'
+			ORDER BY
+				table_name
+		`,
+		// DuckDB 查询检查约束
+		"duckdb": `
+			SELECT
+				k.database_name AS database_name
+			  , k.table_name AS table_name
+			  , k.constraint_name AS constraint_name
+			  , k.expression AS check_clause
+			FROM duckdb_constraints() k
+			WHERE
+				  k.constraint_type = 'CHECK'
+			  AND k.database_name = ?
+			ORDER BY
+				table_name
+			  , constraint_name
+		`,
+		// MariaDB 检查约束自带表名，字段级约束以字段名命名，排除 JSON 字段自动生成的 json_valid 约束
+		"mariadb": `
+			SELECT
+				cc.CONSTRAINT_SCHEMA AS database_name
+			  , cc.TABLE_NAME AS table_name
+			  , cc.CONSTRAINT_NAME AS constraint_name
+			  , cc.CHECK_CLAUSE AS check_clause
+			FROM INFORMATION_SCHEMA.CHECK_CONSTRAINTS cc
+			WHERE
+				  cc.CHECK_CLAUSE NOT LIKE 'json_valid(%'
+			  AND cc.CONSTRAINT_SCHEMA = ?
+			ORDER BY
+				table_name
+			  , constraint_name
+		`,
+	}
+)
@@ -0,0 +1,118 @@
+package services
+
+var (
+	// 库默认字符集及排序规则
+	sql_getDatabaseCollationMap = map[string]string{
+		// SQL Server 字符集以代码页表示
+		"sqlserver": `
+			SELECT
+				d.name AS database_name
+			  , CAST(COLLATIONPROPERTY(d.collation_name, 'CodePage') AS VARCHAR(10)) AS character_set_name
+			  , d.collation_name AS collation_name
+			FROM sys.databases d
+			WHERE
+				d.name = ?
+		`,
+		"mysql": `
+			SELECT
+				s.SCHEMA_NAME AS database_name
+			  , s.DEFAULT_CHARACTER_SET_NAME AS character_set_name
+			  , s.DEFAULT_COLLATION_NAME AS collation_name
+			FROM INFORMATION_SCHEMA.SCHEMATA s
+			WHERE
+				s.SCHEMA_NAME = ?
+		`,
+		"postgres": `
+			SELECT
+				d.datname AS database_name
+			  , pg_encoding_to_char(d.encoding) AS character_set_name
+			  , d.datcollate AS collation_name
+			FROM pg_database d
+			WHERE
+				d.datname = ?
+		`,
+	}
+
+	// 表字符集及排序规则（SQL Server、PostgresSQL 没有表级排序规则）
+	sql_getTableCollationMap = map[string]string{
+		"mysql": `
+			SELECT
+				t.TABLE_SCHEMA AS database_name
+			  , t.TABLE_NAME AS table_name
+			  , ccsa.CHARACTER_SET_NAME AS character_set_name
+			  , t.TABLE_COLLATION AS collation_name
+			FROM INFORMATION_SCHEMA.TABLES t
+				 LEFT JOIN INFORMATION_SCHEMA.COLLATION_CHARACTER_SET_APPLICABILITY ccsa
+				 ON t.TABLE_COLLATION = ccsa.COLLATION_NAME
+			WHERE
+				  t.TABLE_COLLATION IS NOT NULL
+			  AND t.TABLE_SCHEMA = ?
+			ORDER BY
+				table_name
+		`,
+	}
+
+	// 字段字符集及排序规则（仅字符类字段）
+	sql_getColumnCollationMap = map[string]string{
+		"sqlserver": `
+			SELECT
+				DB_NAME() AS database_name
+			  , t.name AS table_name
+			  , c.name AS column_name
+			  , CAST(COLLATIONPROPERTY(c.collation_name, 'CodePage') AS VARCHAR(10)) AS character_set_name
+			  , c.collation_name AS collation_name
+			FROM sys.columns c
+				 JOIN sys.objects t
+				 ON c.object_id = t.object_id
+			WHERE
+				  t.type IN ('U', 'V')
+			  AND c.collation_name IS NOT NULL
+			  AND DB_NAME() = ?
+			ORDER BY
+				table_name
+			  , c.column_id
+		`,
+		"mysql": `
+			SELECT
+				c.TABLE_SCHEMA AS database_name
+			  , c.TABLE_NAME AS table_name
+			  , c.COLUMN_NAME AS column_name
+			  , c.CHARACTER_SET_NAME AS character_set_name
+			  , c.COLLATION_NAME AS collation_name
+			FROM INFORMATION_SCHEMA.COLUMNS c
+			WHERE
+				  c.COLLATION_NAME IS NOT NULL
+			  AND c.TABLE_SCHEMA = ?
+			ORDER BY
+				table_name
+			  , c.ORDINAL_POSITION
+		`,
+		// PostgresSQL 字段使用 default 排序规则时取库默认值
+		"postgres": `
+			SELECT
+				CURRENT_DATABASE() AS database_name
+			  , t.relname AS table_name
+			  , a.attname AS column_name
+			  , pg_encoding_to_char(d.encoding) AS character_set_name
+			  , CASE WHEN co.collname = 'default' THEN d.datcollate ELSE co.collname END AS collation_name
+			FROM pg_attribute a
+				 JOIN pg_class t
+				 ON a.attrelid = t.oid
+				 JOIN pg_namespace n
+				 ON t.relnamespace = n.oid
+				 JOIN pg_collation co
+				 ON a.attcollation = co.oid
+				 JOIN pg_database d
+				 ON d.datname = CURRENT_DATABASE()
+			WHERE
+				  a.attnum > 0
+			  AND NOT a.attisdropped
+			  AND t.relkind IN ('r', 'p', 'v', 'm')
+			  AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+			  AND current_database() = ?
+			ORDER BY
+				table_name
+			  , a.attnum
+		`,
+	}
+)
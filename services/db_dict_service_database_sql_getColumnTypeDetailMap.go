@@ -0,0 +1,165 @@
+package services
+
+var (
+	sql_getColumnTypeDetailMap = map[string]string{
+		// SQL Server 查询使用自定义别名类型的字段
+		"sqlserver": `
+			SELECT
+				DB_NAME() AS database_name
+			  , t.name AS table_name
+			  , c.name AS column_name
+			  , ty.name AS type_name
+			  , TYPE_NAME(ty.system_type_id) +
+				CASE
+					WHEN TYPE_NAME(ty.system_type_id) IN ('varchar', 'char', 'varbinary', 'binary')
+						THEN '(' + CASE ty.max_length WHEN -1 THEN 'max' ELSE CAST(ty.max_length AS VARCHAR(10)) END + ')'
+					WHEN TYPE_NAME(ty.system_type_id) IN ('nvarchar', 'nchar')
+						THEN '(' + CASE ty.max_length WHEN -1 THEN 'max' ELSE CAST(ty.max_length / 2 AS VARCHAR(10)) END + ')'
+					WHEN TYPE_NAME(ty.system_type_id) IN ('decimal', 'numeric')
+						THEN '(' + CAST(ty.precision AS VARCHAR(10)) + ', ' + CAST(ty.scale AS VARCHAR(10)) + ')'
+					ELSE ''
+				END AS type_definition
+			FROM sys.columns c
+				 JOIN sys.tables t
+				 ON c.object_id = t.object_id
+				 JOIN sys.types ty
+				 ON c.user_type_id = ty.user_type_id
+			WHERE
+				  ty.is_user_defined = 1
+			  AND DB_NAME() = ?
+			ORDER BY
+				table_name
+			  , c.column_id
+		`,
+		// MySQL 查询枚举/集合字段
+		"mysql": `
+			SELECT
+				c.TABLE_SCHEMA AS database_name
+			  , c.TABLE_NAME AS table_name
+			  , c.COLUMN_NAME AS column_name
+			  , c.DATA_TYPE AS type_name
+			  , c.COLUMN_TYPE AS type_definition
+			FROM INFORMATION_SCHEMA.COLUMNS c
+			WHERE
+				  c.DATA_TYPE IN ('enum', 'set')
+			  AND c.TABLE_SCHEMA = ?
+			ORDER BY
+				table_name
+			  , c.ORDINAL_POSITION
+		`,
+		// PostgresSQL 查询使用自定义类型（枚举、复合、域等）的字段，类型定义见 sql_getTypeMap
+		"postgres": `
+			SELECT
+				c.table_catalog AS database_name
+			  , c.table_name AS table_name
+			  , c.column_name AS column_name
+			  , COALESCE(c.domain_name, c.udt_name) AS type_name
+			  , NULL AS type_definition
+			FROM information_schema.columns c
+			WHERE
+				  (c.data_type = 'USER-DEFINED' OR c.domain_name IS NOT NULL)
+			  AND c.table_schema NOT IN ('pg_catalog', 'information_schema')
+			  AND c.table_catalog = ?
+			ORDER BY
+				table_name
+			  , c.ordinal_position
+		`,
+		// DuckDB 查询枚举字段（类型定义如 ENUM('a', 'b')）
+		"duckdb": `
+			SELECT
+				c.database_name AS database_name
+			  , c.table_name AS table_name
+			  , c.column_name AS column_name
+			  , 'enum' AS type_name
+			  , c.data_type AS type_definition
+			FROM duckdb_columns() c
+			WHERE
+				  c.data_type LIKE 'ENUM(%'
+			  AND NOT c.internal
+			  AND c.database_name = ?
+			ORDER BY
+				table_name
+			  , c.column_index
+		`,
+	}
+
+	sql_getTypeMap = map[string]string{
+		// PostgresSQL 查询自定义类型
+		"postgres": `
+			SELECT
+				CURRENT_DATABASE() AS database_name
+			  , n.nspname AS schema_name
+			  , t.typname AS type_name
+			  , CASE t.typtype
+					WHEN 'e' THEN 'enum'
+					WHEN 'd' THEN 'domain'
+					WHEN 'c' THEN 'composite'
+					WHEN 'r' THEN 'range'
+				END AS type_kind
+			  , CASE t.typtype
+					WHEN 'e' THEN (
+						SELECT
+							string_agg(e.enumlabel, ', ' ORDER BY e.enumsortorder)
+						FROM pg_enum e
+						WHERE
+							e.enumtypid = t.oid
+					)
+					WHEN 'd' THEN format_type(t.typbasetype, t.typtypmod) || COALESCE(' ' || (
+						SELECT
+							string_agg(pg_get_constraintdef(con.oid), ' ')
+						FROM pg_constraint con
+						WHERE
+							con.contypid = t.oid
+					), '')
+					WHEN 'c' THEN (
+						SELECT
+							string_agg(a.attname || ' ' || format_type(a.atttypid, a.atttypmod), ', ' ORDER BY a.attnum)
+						FROM pg_attribute a
+						WHERE
+							  a.attrelid = t.typrelid
+						  AND a.attnum > 0
+						  AND NOT a.attisdropped
+					)
+					WHEN 'r' THEN (
+						SELECT
+							format_type(r.rngsubtype, NULL)
+						FROM pg_range r
+						WHERE
+							r.rngtypid = t.oid
+					)
+				END AS type_definition
+			  , obj_description(t.oid, 'pg_type') AS comment
+			FROM pg_type t
+				 JOIN pg_namespace n
+				 ON t.typnamespace = n.oid
+			WHERE
+				  n.nspname NOT IN ('pg_catalog', 'information_schema')
+			  AND n.nspname NOT LIKE 'pg_toast%'
+			  AND (
+					t.typtype IN ('e', 'd', 'r')
+					OR (t.typtype = 'c' AND EXISTS (SELECT 1 FROM pg_class cls WHERE cls.oid = t.typrelid AND cls.relkind = 'c'))
+				)
+			  AND current_database() = ?
+			ORDER BY
+				schema_name
+			  , type_name
+		`,
+		// DuckDB 查询自定义类型
+		"duckdb": `
+			SELECT
+				t.database_name AS database_name
+			  , t.schema_name AS schema_name
+			  , t.type_name AS type_name
+			  , LOWER(t.logical_type) AS type_kind
+			  , CASE WHEN t.logical_type = 'ENUM' THEN array_to_string(t.labels, ', ') ELSE t.logical_type END AS type_definition
+			  , t.comment AS comment
+			FROM duckdb_types() t
+			WHERE
+				  NOT t.internal
+			  AND t.database_name = ?
+			ORDER BY
+				schema_name
+			  , type_name
+		`,
+	}
+)
@@ -0,0 +1,125 @@
+package services
+
+var (
+	sql_getGeneratedColumnMap = map[string]string{
+		// SQL Server 查询计算列
+		"sqlserver": `
+			SELECT
+				DB_NAME() AS database_name
+			  , t.name AS table_name
+			  , cc.name AS column_name
+			  , cc.definition AS generation_expression
+			  , cc.is_persisted AS is_stored
+			FROM sys.computed_columns cc
+				 JOIN sys.tables t
+				 ON cc.object_id = t.object_id
+			WHERE
+				DB_NAME() = ?
+			ORDER BY
+				table_name
+			  , column_name
+		`,
+		// MySQL 查询生成列
+		"mysql": `
+			SELECT
+				c.TABLE_SCHEMA AS database_name
+			  , c.TABLE_NAME AS table_name
+			  , c.COLUMN_NAME AS column_name
+			  , c.GENERATION_EXPRESSION AS generation_expression
+			  , CASE WHEN c.EXTRA LIKE '%STORED GENERATED%' THEN 1 ELSE 0 END AS is_stored
+			FROM INFORMATION_SCHEMA.COLUMNS c
+			WHERE
+				  (c.EXTRA LIKE '%VIRTUAL GENERATED%' OR c.EXTRA LIKE '%STORED GENERATED%')
+			  AND c.TABLE_SCHEMA = ?
+			ORDER BY
+				table_name
+			  , c.ORDINAL_POSITION
+		`,
+		// PostgresSQL 查询生成列（PostgresSQL 只支持存储生成列）
+		"postgres": `
+			SELECT
+				c.table_catalog AS database_name
+			  , c.table_name AS table_name
+			  , c.column_name AS column_name
+			  , c.generation_expression AS generation_expression
+			  , 1 AS is_stored
+			FROM information_schema.columns c
+			WHERE
+				  c.is_generated = 'ALWAYS'
+			  AND c.table_schema NOT IN ('pg_catalog', 'information_schema')
+			  AND c.table_catalog = ?
+			ORDER BY
+				table_name
+			  , c.ordinal_position
+		`,
+		// Oracle 查询虚拟列
+		"oracle": `
+			SELECT
+				SYS_CONTEXT('USERENV', 'DB_NAME') AS "database_name"
+			  , c.TABLE_NAME AS "table_name"
+			  , c.COLUMN_NAME AS "column_name"
+			  , c.DATA_DEFAULT AS "generation_expression"
+			  , 0 AS "is_stored"
+			FROM ALL_TAB_COLS c
+			WHERE
+				  c.VIRTUAL_COLUMN = 'YES'
+			  AND c.HIDDEN_COLUMN = 'NO'
+			  AND c.OWNER = UPPER(?)
+			ORDER BY
+				"table_name"
+			  , c.COLUMN_ID
+		`,
+		// SQLite 查询生成列（hidden: 2=虚拟列, 3=存储列），表达式从建表语句中解析
+		"sqlite": `
+			SELECT
+				'main' AS database_name
+			  , m.name AS table_name
+			  , p.name AS column_name
+			  , m.sql AS generation_expression
+			  , CASE WHEN p.hidden = 3 THEN 1 ELSE 0 END AS is_stored
+			FROM sqlite_master m
+				 JOIN pragma_table_xinfo(m.name) p
+			WHERE
+				  m.type = 'table'
+			  AND p.hidden IN (2, 3)
+			ORDER BY
+				table_name
+			  , p.cid
+		`,
+		// DuckDB 生成列与默认值共用 column_default，返回建表语句后再解析（DuckDB 只支持虚拟生成列）
+		"duckdb": `
+			SELECT
+				c.database_name AS database_name
+			  , c.table_name AS table_name
+			  , c.column_name AS column_name
+			  , t.sql AS generation_expression
+			  , 0 AS is_stored
+			FROM duckdb_columns() c
+				 JOIN duckdb_tables() t
+				 ON c.table_oid = t.table_oid
+			WHERE
+				  c.column_default IS NOT NULL
+			  AND t.sql LIKE '%GENERATED ALWAYS AS%'
+			  AND c.database_name = ?
+			ORDER BY
+				table_name
+			  , c.column_index
+		`,
+		// ClickHouse 物化列（MATERIALIZED）存储，别名列（ALIAS）不存储
+		"clickhouse": `
+			SELECT
+				c.database AS database_name
+			  , c.table AS table_name
+			  , c.name AS column_name
+			  , c.default_expression AS generation_expression
+			  , c.default_kind = 'MATERIALIZED' AS is_stored
+			FROM system.columns c
+			WHERE
+				  c.default_kind IN ('MATERIALIZED', 'ALIAS')
+			  AND c.database = ?
+			ORDER BY
+				table_name
+			  , c.position
+		`,
+	}
+)
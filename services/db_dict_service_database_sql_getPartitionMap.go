@@ -0,0 +1,165 @@
+package services
+
+var (
+	sql_getPartitionMap = map[string]string{
+		// SQL Server 查询分区（分区函数 + 分区方案）
+		"sqlserver": `
+			SELECT
+				p.partition_number AS sort
+			  , DB_NAME() AS database_name
+			  , t.name AS table_name
+			  , CONCAT(t.name, '_p', p.partition_number) AS partition_name
+			  , pf.type_desc AS partition_strategy
+			  , c.name AS partition_key
+			  , CASE pf.boundary_value_on_right
+					WHEN 1 THEN '>= ' + CONVERT(NVARCHAR(4000), prv_r.value)
+					ELSE '<= ' + CONVERT(NVARCHAR(4000), prv_l.value)
+				END AS partition_bound
+			  , p.rows AS row_estimate
+			FROM sys.tables t
+				 JOIN sys.indexes i
+				 ON t.object_id = i.object_id
+					 AND i.index_id IN (0, 1)
+				 JOIN sys.partitions p
+				 ON i.object_id = p.object_id
+					 AND i.index_id = p.index_id
+				 JOIN sys.partition_schemes ps
+				 ON i.data_space_id = ps.data_space_id
+				 JOIN sys.partition_functions pf
+				 ON ps.function_id = pf.function_id
+				 JOIN sys.index_columns ic
+				 ON i.object_id = ic.object_id
+					 AND i.index_id = ic.index_id
+					 AND ic.partition_ordinal = 1
+				 JOIN sys.columns c
+				 ON ic.object_id = c.object_id
+					 AND ic.column_id = c.column_id
+				 LEFT JOIN sys.partition_range_values prv_l
+				 ON pf.function_id = prv_l.function_id
+					 AND prv_l.boundary_id = p.partition_number
+				 LEFT JOIN sys.partition_range_values prv_r
+				 ON pf.function_id = prv_r.function_id
+					 AND prv_r.boundary_id = p.partition_number - 1
+			WHERE
+				DB_NAME() = ?
+			ORDER BY
+				table_name
+			  , sort
+		`,
+		// MySQL 查询分区（忽略子分区）
+		"mysql": `
+			SELECT
+				p.PARTITION_ORDINAL_POSITION AS sort
+			  , p.TABLE_SCHEMA AS database_name
+			  , p.TABLE_NAME AS table_name
+			  , p.PARTITION_NAME AS partition_name
+			  , p.PARTITION_METHOD AS partition_strategy
+			  , p.PARTITION_EXPRESSION AS partition_key
+			  , p.PARTITION_DESCRIPTION AS partition_bound
+			  , SUM(p.TABLE_ROWS) AS row_estimate
+			FROM INFORMATION_SCHEMA.PARTITIONS p
+			WHERE
+				  p.PARTITION_NAME IS NOT NULL
+			  AND p.TABLE_SCHEMA = ?
+			GROUP BY
+				p.TABLE_SCHEMA
+			  , p.TABLE_NAME
+			  , p.PARTITION_NAME
+			  , p.PARTITION_ORDINAL_POSITION
+			  , p.PARTITION_METHOD
+			  , p.PARTITION_EXPRESSION
+			  , p.PARTITION_DESCRIPTION
+			ORDER BY
+				table_name
+			  , sort
+		`,
+		// PostgresSQL 查询分区（子分区本身也是一张表）
+		"postgres": `
+			SELECT
+				ROW_NUMBER() OVER (PARTITION BY parent.oid ORDER BY child.relname) AS sort
+			  , CURRENT_DATABASE() AS database_name
+			  , parent.relname AS table_name
+			  , child.relname AS partition_name
+			  , CASE pt.partstrat
+					WHEN 'r' THEN 'RANGE'
+					WHEN 'l' THEN 'LIST'
+					WHEN 'h' THEN 'HASH'
+					ELSE pt.partstrat::text
+				END AS partition_strategy
+			  , regexp_replace(pg_get_partkeydef(parent.oid), '^\w+\s*', '') AS partition_key
+			  , pg_get_expr(child.relpartbound, child.oid) AS partition_bound
+			  , GREATEST(child.reltuples, 0)::bigint AS row_estimate
+			FROM pg_inherits inh
+				 JOIN pg_class parent
+				 ON inh.inhparent = parent.oid
+				 JOIN pg_class child
+				 ON inh.inhrelid = child.oid
+				 JOIN pg_partitioned_table pt
+				 ON parent.oid = pt.partrelid
+				 JOIN pg_namespace n
+				 ON parent.relnamespace = n.oid
+			WHERE
+				  n.nspname NOT IN ('pg_catalog', 'information_schema')
+			  AND current_database() = ?
+			ORDER BY
+				table_name
+			  , sort
+		`,
+		// Oracle 查询分区
+		"oracle": `
+			SELECT
+				tp.PARTITION_POSITION AS "sort"
+			  , SYS_CONTEXT('USERENV', 'DB_NAME') AS "database_name"
+			  , tp.TABLE_NAME AS "table_name"
+			  , tp.PARTITION_NAME AS "partition_name"
+			  , pt.PARTITIONING_TYPE AS "partition_strategy"
+			  , (
+					SELECT
+						LISTAGG(k.COLUMN_NAME, ', ') WITHIN GROUP (ORDER BY k.COLUMN_POSITION)
+					FROM ALL_PART_KEY_COLUMNS k
+					WHERE
+						  k.OWNER = tp.TABLE_OWNER
+					  AND k.NAME = tp.TABLE_NAME
+					  AND k.OBJECT_TYPE = 'TABLE'
+				) AS "partition_key"
+			  , tp.HIGH_VALUE AS "partition_bound"
+			  , tp.NUM_ROWS AS "row_estimate"
+			FROM ALL_TAB_PARTITIONS tp
+				 JOIN ALL_PART_TABLES pt
+				 ON tp.TABLE_OWNER = pt.OWNER
+					 AND tp.TABLE_NAME = pt.TABLE_NAME
+			WHERE
+				tp.TABLE_OWNER = UPPER(?)
+			ORDER BY
+				"table_name"
+			  , "sort"
+		`,
+		// ClickHouse 分区来自活动数据片段
+		"clickhouse": `
+			SELECT
+				0 AS sort
+			  , p.database AS database_name
+			  , p.table AS table_name
+			  , p.partition AS partition_name
+			  , 'PARTITION BY' AS partition_strategy
+			  , any(t.partition_key) AS partition_key
+			  , p.partition_id AS partition_bound
+			  , sum(p.rows) AS row_estimate
+			FROM system.parts p
+				 JOIN system.tables t
+				 ON p.database = t.database
+					 AND p.table = t.name
+			WHERE
+				  p.active
+			  AND p.database = ?
+			GROUP BY
+				p.database
+			  , p.table
+			  , p.partition
+			  , p.partition_id
+			ORDER BY
+				table_name
+			  , partition_name
+		`,
+	}
+)
@@ -0,0 +1,109 @@
+package services
+
+var (
+	sql_getSequenceMap = map[string]string{
+		// SQL Server 查询序列（通过默认值约束 NEXT VALUE FOR 找到所属字段）
+		"sqlserver": `
+			SELECT
+				DB_NAME() AS database_name
+			  , s.name AS sequence_name
+			  , CAST(s.start_value AS BIGINT) AS start_value
+			  , CAST(s.increment AS BIGINT) AS increment_by
+			  , t.name AS table_name
+			  , c.name AS column_name
+			FROM sys.sequences s
+				 LEFT JOIN sys.default_constraints dc
+				 ON dc.definition LIKE '%NEXT VALUE FOR%'
+					 AND CHARINDEX(QUOTENAME(s.name), dc.definition) > 0
+				 LEFT JOIN sys.tables t
+				 ON dc.parent_object_id = t.object_id
+				 LEFT JOIN sys.columns c
+				 ON dc.parent_object_id = c.object_id
+					 AND dc.parent_column_id = c.column_id
+			WHERE
+				DB_NAME() = ?
+			ORDER BY
+				sequence_name
+		`,
+		// PostgresSQL 查询序列（通过 pg_depend 找到 OWNED BY 的字段）
+		"postgres": `
+			SELECT
+				CURRENT_DATABASE() AS database_name
+			  , s.sequencename AS sequence_name
+			  , s.start_value AS start_value
+			  , s.increment_by AS increment_by
+			  , t.relname AS table_name
+			  , a.attname AS column_name
+			FROM pg_sequences s
+				 JOIN pg_namespace sn
+				 ON sn.nspname = s.schemaname
+				 JOIN pg_class sc
+				 ON sc.relname = s.sequencename
+					 AND sc.relnamespace = sn.oid
+				 LEFT JOIN pg_depend d
+				 ON d.objid = sc.oid
+					 AND d.classid = 'pg_class'::regclass
+					 AND d.refclassid = 'pg_class'::regclass
+					 AND d.deptype IN ('a', 'i')
+				 LEFT JOIN pg_class t
+				 ON d.refobjid = t.oid
+				 LEFT JOIN pg_attribute a
+				 ON a.attrelid = d.refobjid
+					 AND a.attnum = d.refobjsubid
+			WHERE
+				  s.schemaname NOT IN ('pg_catalog', 'information_schema')
+			  AND current_database() = ?
+			ORDER BY
+				sequence_name
+		`,
+		// Oracle 查询序列（通过标识列找到所属字段）
+		"oracle": `
+			SELECT
+				SYS_CONTEXT('USERENV', 'DB_NAME') AS "database_name"
+			  , s.SEQUENCE_NAME AS "sequence_name"
+			  , s.MIN_VALUE AS "start_value"
+			  , s.INCREMENT_BY AS "increment_by"
+			  , idc.TABLE_NAME AS "table_name"
+			  , idc.COLUMN_NAME AS "column_name"
+			FROM ALL_SEQUENCES s
+				 LEFT JOIN ALL_TAB_IDENTITY_COLS idc
+				 ON s.SEQUENCE_OWNER = idc.OWNER
+					 AND s.SEQUENCE_NAME = idc.SEQUENCE_NAME
+			WHERE
+				s.SEQUENCE_OWNER = UPPER(?)
+			ORDER BY
+				"sequence_name"
+		`,
+		// DuckDB 查询序列（所属字段从默认值 nextval 中提取）
+		"duckdb": `
+			SELECT
+				s.database_name AS database_name
+			  , s.sequence_name AS sequence_name
+			  , s.start_value AS start_value
+			  , s.increment_by AS increment_by
+			  , NULL AS table_name
+			  , NULL AS column_name
+			FROM duckdb_sequences() s
+			WHERE
+				  NOT s.temporary
+			  AND s.database_name = ?
+			ORDER BY
+				sequence_name
+		`,
+		// MariaDB 查询序列（11.5 及以上版本提供 INFORMATION_SCHEMA.SEQUENCES，所属字段从默认值 nextval 中提取）
+		"mariadb": `
+			SELECT
+				s.SEQUENCE_SCHEMA AS database_name
+			  , s.SEQUENCE_NAME AS sequence_name
+			  , s.START_VALUE AS start_value
+			  , s.INCREMENT AS increment_by
+			  , NULL AS table_name
+			  , NULL AS column_name
+			FROM INFORMATION_SCHEMA.SEQUENCES s
+			WHERE
+				s.SEQUENCE_SCHEMA = ?
+			ORDER BY
+				sequence_name
+		`,
+	}
+)
@@ -213,7 +213,7 @@ var (
 		`,
 		"sqlite": `
 			SELECT 
-				m.rowid AS 'sort',
+				p.cid + 1 AS 'sort',
 				'main' AS 'database_name',
 				'main' AS 'schema_name',
 				m.name AS 'table_name',
@@ -242,7 +242,7 @@ var (
 				p.dflt_value AS 'default',
 				NULL AS 'comment'
 			FROM sqlite_master m
-			JOIN pragma_table_info(m.name) p ON 1=1
+			JOIN pragma_table_xinfo(m.name) p ON p.hidden <> 1
 			LEFT JOIN (
 				-- 获取唯一约束的字段信息
 				SELECT 
@@ -254,9 +254,88 @@ var (
 				WHERE m.type = 'table'
 				AND il.origin = 'u'  -- 'u' 表示唯一约束[1](@ref)
 			) ui ON m.name = ui.table_name AND p.name = ui.column_name
-			WHERE m.type = 'table'
+			WHERE m.type IN ('table', 'view')
 -- 			AND m.name = ?
 			ORDER BY m.name, p.cid;
 		`,
+		"duckdb": `
+			SELECT
+				c.column_index AS sort
+			  , c.database_name AS database_name
+			  , c.schema_name AS schema_name
+			  , c.table_name AS table_name
+			  , c.column_name AS column_name
+			  , c.data_type AS data_type
+			  , c.character_maximum_length AS length
+			  , c.numeric_precision AS precision
+			  , c.numeric_precision_radix AS radix
+			  , c.numeric_scale AS scale
+			  , CASE WHEN c.is_nullable THEN 1 ELSE 0 END AS nullable
+			  , CASE WHEN pk.column_name IS NOT NULL THEN 1 ELSE 0 END AS is_primary
+			  , CASE WHEN c.column_default LIKE 'nextval%' THEN 1 ELSE 0 END AS is_auto_increment
+			  , CASE WHEN uni.column_name IS NOT NULL THEN 1 ELSE 0 END AS is_unique
+			  , c.column_default AS "default"
+			  , c.comment AS comment
+			FROM duckdb_columns() c
+				 LEFT JOIN (
+							   SELECT DISTINCT
+								   k.database_name
+								 , k.schema_name
+								 , k.table_name
+								 , UNNEST(k.constraint_column_names) AS column_name
+							   FROM duckdb_constraints() k
+							   WHERE k.constraint_type = 'PRIMARY KEY'
+						   ) pk
+				 ON c.database_name = pk.database_name
+					 AND c.schema_name = pk.schema_name
+					 AND c.table_name = pk.table_name
+					 AND c.column_name = pk.column_name
+				 LEFT JOIN (
+							   SELECT DISTINCT
+								   k.database_name
+								 , k.schema_name
+								 , k.table_name
+								 , UNNEST(k.constraint_column_names) AS column_name
+							   FROM duckdb_constraints() k
+							   WHERE k.constraint_type = 'UNIQUE'
+						   ) uni
+				 ON c.database_name = uni.database_name
+					 AND c.schema_name = uni.schema_name
+					 AND c.table_name = uni.table_name
+					 AND c.column_name = uni.column_name
+			WHERE
+				  NOT c.internal
+			  AND c.database_name = ?
+			ORDER BY
+				c.table_name
+			  , c.column_index
+		`,
+		// ClickHouse 没有唯一约束及自增字段
+		"clickhouse": `
+			SELECT
+				c.position AS sort
+			  , c.database AS database_name
+			  , c.database AS schema_name
+			  , c.table AS table_name
+			  , c.name AS column_name
+			  , c.type AS data_type
+			  , c.character_octet_length AS length
+			  , c.numeric_precision AS precision
+			  , c.numeric_precision_radix AS radix
+			  , c.numeric_scale AS scale
+			  , CASE WHEN startsWith(c.type, 'Nullable(') THEN 1 ELSE 0 END AS nullable
+			  , c.is_in_primary_key AS is_primary
+			  , 0 AS is_auto_increment
+			  , 0 AS is_unique
+			  , c.default_expression AS default
+			  , c.comment AS comment
+			  , c.compression_codec AS codec
+			FROM system.columns c
+			WHERE
+				c.database = ?
+			ORDER BY
+				c.table
+			  , c.position
+		`,
 	}
 )
@@ -0,0 +1,40 @@
+package services
+
+var (
+	sql_getTableEngineMap = map[string]string{
+		// MySQL 查询表引擎
+		"mysql": `
+			SELECT
+				t.TABLE_SCHEMA AS database_name
+			  , t.TABLE_NAME AS table_name
+			  , t.ENGINE AS engine
+			  , NULL AS partition_key
+			  , NULL AS sorting_key
+			  , NULL AS primary_key
+			  , NULL AS engine_full
+			FROM INFORMATION_SCHEMA.TABLES t
+			WHERE
+				  t.ENGINE IS NOT NULL
+			  AND t.TABLE_SCHEMA = ?
+			ORDER BY
+				table_name
+		`,
+		// ClickHouse 查询表引擎、分区键、排序键、主键（TTL 从 engine_full 中解析）
+		"clickhouse": `
+			SELECT
+				t.database AS database_name
+			  , t.name AS table_name
+			  , t.engine AS engine
+			  , t.partition_key AS partition_key
+			  , t.sorting_key AS sorting_key
+			  , t.primary_key AS primary_key
+			  , t.engine_full AS engine_full
+			FROM system.tables t
+			WHERE
+				  NOT t.is_temporary
+			  AND t.database = ?
+			ORDER BY
+				table_name
+		`,
+	}
+)
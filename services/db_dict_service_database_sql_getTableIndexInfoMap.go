@@ -105,7 +105,7 @@ var (
 				 ON a.attrelid = t.oid AND a.attnum = ANY (idx.indkey
 				 )
 			WHERE
-				  t.relkind = 'r'
+				  t.relkind IN ('r', 'p') -- 普通表及分区父表
 			  AND n.nspname NOT IN ('pg_catalog', 'information_schema')
 			  AND current_database() = ?
 			GROUP BY
@@ -207,5 +207,82 @@ var (
 			  , table_name
 			  , index_type
 		`,
+		// DuckDB 主键、唯一约束不在 duckdb_indexes() 中，需要从 duckdb_constraints() 合并；
+		// expressions 中与关键字同名的列带双引号（如 "name"），去掉引号与其他数据库一致
+		"duckdb": `
+			SELECT
+				i.database_name AS database_name
+			  , i.schema_name AS schema_name
+			  , i.table_name AS table_name
+			  , i.index_name AS index_name
+			  , 'ART' AS index_type
+			  , REPLACE(TRIM(CAST(i.expressions AS VARCHAR), '[]'), '"', '') AS column_names
+			  , i.is_unique AS is_unique
+			  , i.is_primary AS is_primary
+			  , i.comment AS index_comment
+			FROM duckdb_indexes() i
+			WHERE
+				i.database_name = ?
+			UNION ALL
+			SELECT
+				k.database_name AS database_name
+			  , k.schema_name AS schema_name
+			  , k.table_name AS table_name
+			  , k.constraint_name AS index_name
+			  , 'ART' AS index_type
+			  , array_to_string(k.constraint_column_names, ', ') AS column_names
+			  , TRUE AS is_unique
+			  , k.constraint_type = 'PRIMARY KEY' AS is_primary
+			  , NULL AS index_comment
+			FROM duckdb_constraints() k
+			WHERE
+				  k.constraint_type IN ('PRIMARY KEY', 'UNIQUE')
+			  AND k.database_name = ?
+			ORDER BY
+				database_name
+			  , schema_name
+			  , table_name
+			  , index_name
+		`,
+		// ClickHouse 数据跳数索引，主键（稀疏索引）从 system.tables 合并
+		"clickhouse": `
+			SELECT
+				*
+			FROM (
+					 SELECT
+						 i.database AS database_name
+					   , i.database AS schema_name
+					   , i.table AS table_name
+					   , i.name AS index_name
+					   , i.type_full AS index_type
+					   , i.expr AS column_names
+					   , 0 AS is_unique
+					   , 0 AS is_primary
+					   , concat('GRANULARITY ', toString(i.granularity)) AS index_comment
+					 FROM system.data_skipping_indices i
+					 WHERE
+						 i.database = ?
+					 UNION ALL
+					 SELECT
+						 t.database AS database_name
+					   , t.database AS schema_name
+					   , t.name AS table_name
+					   , 'PRIMARY' AS index_name
+					   , 'primary' AS index_type
+					   , t.primary_key AS column_names
+					   , 0 AS is_unique
+					   , 1 AS is_primary
+					   , '' AS index_comment
+					 FROM system.tables t
+					 WHERE
+						   t.primary_key <> ''
+					   AND t.database = ?
+				 )
+			ORDER BY
+				database_name
+			  , schema_name
+			  , table_name
+			  , index_name
+		`,
 	}
 )
@@ -27,11 +27,13 @@ var (
 					 WHEN 'BASE TABLE' THEN 'table'
 					 WHEN 'VIEW' THEN 'view'
 					 WHEN 'SYSTEM VIEW' THEN 'sys_view'
+					 WHEN 'SYSTEM VERSIONED' THEN 'table' -- MariaDB 系统版本表
 					 ELSE 'Other'
 				END) AS table_type
 			FROM INFORMATION_SCHEMA.TABLES t
 			WHERE
-				t.TABLE_SCHEMA = ?
+				  t.TABLE_TYPE <> 'SEQUENCE' -- MariaDB 序列
+			  AND t.TABLE_SCHEMA = ?
 			ORDER BY
 			    table_name
 			  , table_type
@@ -86,5 +88,36 @@ var (
 			    table_name
 			  , table_type
         `,
+		"duckdb": `
+			SELECT
+				t.table_name AS table_name
+			  , (CASE t.table_type
+					 WHEN 'BASE TABLE' THEN 'table'
+					 WHEN 'VIEW' THEN 'view'
+					 ELSE 'Other'
+				END) AS table_type
+			FROM information_schema.tables t
+			WHERE
+				  t.table_schema NOT IN ('pg_catalog', 'information_schema')
+			  AND t.table_catalog = ?
+			ORDER BY
+				table_name
+			  , table_type
+		`,
+		"clickhouse": `
+			SELECT
+				t.name AS table_name
+			  , (CASE
+					 WHEN t.engine IN ('View', 'MaterializedView', 'LiveView', 'WindowView') THEN 'view'
+					 ELSE 'table'
+				END) AS table_type
+			FROM system.tables t
+			WHERE
+				  NOT t.is_temporary
+			  AND t.database = ?
+			ORDER BY
+				table_name
+			  , table_type
+		`,
 	}
 )
@@ -0,0 +1,96 @@
+package services
+
+var (
+	sql_getViewDefinitionMap = map[string]string{
+		// SQL Server 查询视图定义
+		"sqlserver": `
+			SELECT
+				DB_NAME() AS database_name
+			  , v.name AS table_name
+			  , m.definition AS view_definition
+			FROM sys.views v
+				 JOIN sys.sql_modules m
+				 ON v.object_id = m.object_id
+			WHERE
+				  v.is_ms_shipped = 0
+			  AND DB_NAME() = ?
+			ORDER BY
+				table_name
+		`,
+		// MySQL 查询视图定义
+		"mysql": `
+			SELECT
+				v.TABLE_SCHEMA AS database_name
+			  , v.TABLE_NAME AS table_name
+			  , v.VIEW_DEFINITION AS view_definition
+			FROM INFORMATION_SCHEMA.VIEWS v
+			WHERE
+				v.TABLE_SCHEMA = ?
+			ORDER BY
+				table_name
+		`,
+		// PostgresSQL 查询视图定义
+		"postgres": `
+			SELECT
+				CURRENT_DATABASE() AS database_name
+			  , v.viewname AS table_name
+			  , v.definition AS view_definition
+			FROM pg_views v
+			WHERE
+				  v.schemaname NOT IN ('pg_catalog', 'information_schema')
+			  AND current_database() = ?
+			ORDER BY
+				table_name
+		`,
+		// Oracle 查询视图定义
+		"oracle": `
+			SELECT
+				SYS_CONTEXT('USERENV', 'DB_NAME') AS "database_name"
+			  , v.VIEW_NAME AS "table_name"
+			  , v.TEXT AS "view_definition"
+			FROM ALL_VIEWS v
+			WHERE
+				v.OWNER = UPPER(?)
+			ORDER BY
+				"table_name"
+		`,
+		// SQLite 查询视图定义
+		"sqlite": `
+			SELECT
+				'main' AS database_name
+			  , t.name AS table_name
+			  , t.sql AS view_definition
+			FROM sqlite_master t
+			WHERE
+				t.type = 'view'
+			ORDER BY
+				table_name
+		`,
+		// DuckDB 返回完整的建视图语句
+		"duckdb": `
+			SELECT
+				v.database_name AS database_name
+			  , v.view_name AS table_name
+			  , v.sql AS view_definition
+			FROM duckdb_views() v
+			WHERE
+				  NOT v.internal
+			  AND v.database_name = ?
+			ORDER BY
+				table_name
+		`,
+		// ClickHouse 视图及物化视图
+		"clickhouse": `
+			SELECT
+				t.database AS database_name
+			  , t.name AS table_name
+			  , t.as_select AS view_definition
+			FROM system.tables t
+			WHERE
+				  t.engine IN ('View', 'MaterializedView')
+			  AND t.database = ?
+			ORDER BY
+				table_name
+		`,
+	}
+)
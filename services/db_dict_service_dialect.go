@@ -0,0 +1,46 @@
+package services
+
+// 兼容方言：未单独定义的查询沿用基础方言的查询
+var compatibleDialectMap = map[string]string{
+	"mariadb": "mysql",
+	"tidb":    "mysql",
+}
+
+func init() {
+	sqlMapList := []map[string]string{
+		sql_getTableTypeMap,
+		sql_getTableColumnInfosMap,
+		sql_getTableIndexInfoMap,
+		sql_getViewDefinitionMap,
+		sql_getCheckConstraintMap,
+		sql_getGeneratedColumnMap,
+		sql_getSequenceMap,
+		sql_getPartitionMap,
+		sql_getColumnTypeDetailMap,
+		sql_getTypeMap,
+		sql_getDatabaseCollationMap,
+		sql_getTableCollationMap,
+		sql_getColumnCollationMap,
+		sql_getAnnotationMap,
+		sql_getTableEngineMap,
+	}
+	for _, sqlMap := range sqlMapList {
+		for dialect, baseDialect := range compatibleDialectMap {
+			if _, ok := sqlMap[dialect]; ok {
+				continue
+			}
+			if query, ok := sqlMap[baseDialect]; ok {
+				sqlMap[dialect] = query
+			}
+		}
+	}
+}
+
+// getBaseDialectName 获取基础方言名称，如 mariadb、tidb 返回 mysql
+func (this *DbDictService) getBaseDialectName() string {
+	dbType := this.DB.Dialector.Name()
+	if baseDialect, ok := compatibleDialectMap[dbType]; ok {
+		return baseDialect
+	}
+	return dbType
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"database/sql"
+	"goDict/configs"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// openDuckDBFixture 在临时目录中创建 DuckDB 数据库（表、主键、唯一约束、索引、注释、视图），以只读方式连接
+func openDuckDBFixture(t *testing.T) (*DbDictService, *configs.DatabaseConfig) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "shop.duckdb")
+	db, err := sql.Open("duckdb", path)
+	if nil != err {
+		t.Fatal(err)
+	}
+	for _, statement := range []string{
+		`CREATE TABLE customer (
+			id INTEGER PRIMARY KEY,
+			email VARCHAR(100) NOT NULL UNIQUE,
+			name VARCHAR,
+			score DECIMAL(10, 2) DEFAULT 0
+		)`,
+		`CREATE INDEX idx_customer_name ON customer (name)`,
+		`COMMENT ON TABLE customer IS '客户'`,
+		`COMMENT ON COLUMN customer.name IS '姓名'`,
+		`CREATE VIEW vip_customer AS SELECT id, name FROM customer WHERE score > 100`,
+	} {
+		if _, err = db.Exec(statement); nil != err {
+			t.Fatalf("%s: %v", statement, err)
+		}
+	}
+	_ = db.Close()
+
+	dbConfig := &configs.DatabaseConfig{Type: "DuckDB", Host: path}
+	gormDB, err := configs.InitDatabase(dbConfig)
+	if nil != err {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = configs.CloseDatabase(gormDB) })
+	return &DbDictService{DB: gormDB}, dbConfig
+}
+
+func TestDuckDBMetadata(t *testing.T) {
+	service, dbConfig := openDuckDBFixture(t)
+
+	// 表类型
+	tableTypeMap, err := service.getTableType(dbConfig)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if 2 != len(tableTypeMap) || "table" != tableTypeMap["customer"] || "view" != tableTypeMap["vip_customer"] {
+		t.Errorf("table type map = %v, want map[customer:table vip_customer:view]", tableTypeMap)
+	}
+
+	// 表注释
+	tableCommentMap, err := service.getTableComment(dbConfig)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if "客户" != tableCommentMap["customer"] {
+		t.Errorf("table comment = %q, want 客户", tableCommentMap["customer"])
+	}
+
+	// 列
+	columnInfoMap, err := service.getTableColumnInfoMap(dbConfig)
+	if nil != err {
+		t.Fatal(err)
+	}
+	columnInfoList := columnInfoMap["customer"]
+	if 4 != len(columnInfoList) {
+		t.Fatalf("customer columns = %d, want 4", len(columnInfoList))
+	}
+	columnNameList := []string{}
+	for _, columnInfo := range columnInfoList {
+		columnNameList = append(columnNameList, columnInfo.ColumnName)
+		if "shop" != columnInfo.DatabaseName || "main" != columnInfo.SchemaName {
+			t.Errorf("%s: database.schema = %s.%s, want shop.main", columnInfo.ColumnName, columnInfo.DatabaseName, columnInfo.SchemaName)
+		}
+	}
+	if "id,email,name,score" != strings.Join(columnNameList, ",") {
+		t.Errorf("column order = %v, want [id email name score]", columnNameList)
+	}
+	id, email, name, score := columnInfoList[0], columnInfoList[1], columnInfoList[2], columnInfoList[3]
+	if !id.IsPrimary || id.Nullable || "INTEGER" != id.DataType {
+		t.Errorf("id = %+v, want primary INTEGER not null", id)
+	}
+	if !email.IsUnique || email.Nullable || email.IsPrimary {
+		t.Errorf("email = %+v, want unique not null", email)
+	}
+	if !name.Nullable || "姓名" != name.Comment {
+		t.Errorf("name = %+v, want nullable with comment 姓名", name)
+	}
+	if 10 != score.Precision || 2 != score.Scale || "0" != strings.TrimSuffix(score.Default, ".00") {
+		t.Errorf("score = %+v, want DECIMAL(10,2) default 0", score)
+	}
+	if 2 != len(columnInfoMap["vip_customer"]) {
+		t.Errorf("vip_customer columns = %d, want 2", len(columnInfoMap["vip_customer"]))
+	}
+
+	// 索引：主键、唯一约束及普通索引
+	indexInfoMap, err := service.getTableIndexInfoMap(dbConfig)
+	if nil != err {
+		t.Fatal(err)
+	}
+	indexList := indexInfoMap["customer"]
+	if 3 != len(indexList) {
+		t.Fatalf("customer indexes = %+v, want 3", indexList)
+	}
+	var primaryCount, uniqueCount int
+	for _, indexInfo := range indexList {
+		switch {
+		case indexInfo.IsPrimary:
+			primaryCount++
+			if "id" != indexInfo.ColumnNames || !indexInfo.IsUnique {
+				t.Errorf("primary key = %+v, want unique on id", indexInfo)
+			}
+		case indexInfo.IsUnique:
+			uniqueCount++
+			if "email" != indexInfo.ColumnNames {
+				t.Errorf("unique = %+v, want on email", indexInfo)
+			}
+		default:
+			if "idx_customer_name" != indexInfo.IndexName || "name" != indexInfo.ColumnNames {
+				t.Errorf("index = %+v, want idx_customer_name on name", indexInfo)
+			}
+		}
+	}
+	if 1 != primaryCount || 1 != uniqueCount {
+		t.Errorf("primary = %d, unique = %d, want 1 and 1", primaryCount, uniqueCount)
+	}
+
+	// 视图定义
+	viewDefinitionMap, err := service.getViewDefinitionMap(dbConfig)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if definition := viewDefinitionMap["vip_customer"]; !strings.Contains(strings.ToUpper(definition), "CREATE VIEW") ||
+		!strings.Contains(definition, "score > 100") {
+		t.Errorf("view definition = %q, want CREATE VIEW ... score > 100", definition)
+	}
+	if 1 != len(viewDefinitionMap) {
+		t.Errorf("view definition map = %v, want only vip_customer", viewDefinitionMap)
+	}
+}
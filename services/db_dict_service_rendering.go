@@ -28,6 +28,12 @@ var ExcelTableRowMap = map[string]int{
 	"ColumnList": 10,
 }
 
+// Excel 序列区块表头
+var excelSequenceHeaderList = []string{"序列名\nSequence", "起始值\nStart", "步长\nIncrement", "所属字段\nOwner Column"}
+
+// Excel 自定义类型区块表头
+var excelTypeHeaderList = []string{"类型名\nType", "分类\nKind", "定义\nDefinition", "说明\nMemo"}
+
 // RENDERING_FUNC 渲染函数map
 var RENDERING_FUNC = map[string]func(dbConfig *configs.DatabaseConfig, templateData interface{}, outputDirPath string, overwrite bool, total int, current int) (string, error){
 	"md":   renderingMarkdown,
@@ -82,8 +88,8 @@ func renderingMarkdown(dbConfig *configs.DatabaseConfig, templateData interface{
 		return "", fmt.Errorf("不支持的数据类型")
 	}
 
-	// SQLite 保存文件名取原始文件名
-	if "SQLite" == dbConfig.Type {
+	// SQLite、DuckDB 保存文件名取原始文件名
+	if "SQLite" == dbConfig.Type || "DuckDB" == dbConfig.Type {
 		// 原始文件名
 		srcFileName := filepath.Base(dbConfig.Host)
 		// 去掉扩展名
@@ -311,6 +317,24 @@ func renderingExcelDatabase(dbConfig *configs.DatabaseConfig, templateData inter
 	// 设置样式
 	doc.SetCellStyle(sheetName, fmt.Sprintf("B%d", tableRowNo), fmt.Sprintf("D%d", tableRowNo+len(selectedTableInfoMap)-1), tableStyle1)
 
+	// 全库序列
+	sectionRowNo := tableRowNo + len(selectedTableInfoMap) + 1
+	if 0 < len(objInfo.SequenceList) {
+		sectionRowNo = renderingExcelSection(doc, sheetName, sectionRowNo, "序列/Sequence", excelSequenceHeaderList, excelSequenceRowList(objInfo.SequenceList), tableStyle1)
+	}
+	// 全库自定义类型
+	if 0 < len(objInfo.TypeList) {
+		sectionRowNo = renderingExcelSection(doc, sheetName, sectionRowNo, "类型/Type", excelTypeHeaderList, excelTypeRowList(objInfo.TypeList), tableStyle1)
+	}
+	// 库默认排序规则及不一致的表
+	if "" != objInfo.CollationName {
+		rowList := [][]string{{"(" + objInfo.DatabaseName + ")", objInfo.CharacterSetName + " / " + objInfo.CollationName, "-"}}
+		for _, mismatchInfo := range objInfo.GetCollationMismatchList() {
+			rowList = append(rowList, []string{mismatchInfo.TableName, mismatchInfo.CollationName, mismatchInfo.GetColumnText()})
+		}
+		renderingExcelSection(doc, sheetName, sectionRowNo, "排序规则/Collation", []string{"表名\nTable", "排序规则\nCollation", "不一致字段\nMismatched Columns"}, rowList, tableStyle1)
+	}
+
 	return nil
 }
 
@@ -339,7 +363,12 @@ func renderingExcelTable(dbConfig *configs.DatabaseConfig, templateData interfac
 	// 表名
 	doc.SetCellValue(sheetName, fmt.Sprintf("B%d", ExcelTableRowMap["TableName"]), objInfo.TableName)
 	// 类型
-	doc.SetCellValue(sheetName, fmt.Sprintf("B%d", ExcelTableRowMap["TableType"]), objInfo.TableType)
+	// 类型（附带方言标注）
+	tableType := objInfo.TableType
+	if 0 < len(objInfo.AnnotationList) {
+		tableType += " (" + strings.Join(objInfo.AnnotationList, ", ") + ")"
+	}
+	doc.SetCellValue(sheetName, fmt.Sprintf("B%d", ExcelTableRowMap["TableType"]), tableType)
 	// 说明
 	doc.SetCellValue(sheetName, fmt.Sprintf("B%d", ExcelTableRowMap["Comment"]), objInfo.Comment)
 
@@ -376,8 +405,23 @@ func renderingExcelTable(dbConfig *configs.DatabaseConfig, templateData interfac
 		tableRow := tableRowNo + idx
 		// 设置内容
 		doc.SetCellValue(sheetName, fmt.Sprintf("B%d", tableRow), colValue.ColumnName)
-		doc.SetCellValue(sheetName, fmt.Sprintf("C%d", tableRow), colValue.DataType)
-		doc.SetCellValue(sheetName, fmt.Sprintf("D%d", tableRow), fmt.Sprintf("%d, %d, %d", colValue.Precision, colValue.Scale, colValue.Radix))
+		// 类型（附带方言标注、压缩编码）
+		dataType := colValue.DataType
+		if "" != colValue.Annotation {
+			dataType += " (" + colValue.Annotation + ")"
+		}
+		if "" != colValue.Codec {
+			dataType += " " + colValue.Codec
+		}
+		doc.SetCellValue(sheetName, fmt.Sprintf("C%d", tableRow), dataType)
+		// 枚举/集合显示可选值，自定义类型显示底层定义
+		if "" != colValue.EnumValues {
+			doc.SetCellValue(sheetName, fmt.Sprintf("D%d", tableRow), colValue.EnumValues)
+		} else if "" != colValue.TypeDefinition {
+			doc.SetCellValue(sheetName, fmt.Sprintf("D%d", tableRow), colValue.TypeDefinition)
+		} else {
+			doc.SetCellValue(sheetName, fmt.Sprintf("D%d", tableRow), fmt.Sprintf("%d, %d, %d", colValue.Precision, colValue.Scale, colValue.Radix))
+		}
 		doc.SetCellValue(sheetName, fmt.Sprintf("E%d", tableRow), yesNoMap[colValue.Nullable])
 		doc.SetCellValue(sheetName, fmt.Sprintf("F%d", tableRow), colValue.Default)
 		doc.SetCellValue(sheetName, fmt.Sprintf("G%d", tableRow), yesNoMap[colValue.IsPrimary])
@@ -410,6 +454,73 @@ func renderingExcelTable(dbConfig *configs.DatabaseConfig, templateData interfac
 	// 设置文字居中
 	doc.SetCellStyle(sheetName, fmt.Sprintf("B%d", tableRowNo), fmt.Sprintf("J%d", tableRowNo+len(indexList)-1), tableStyle1)
 
+	// 往下移动索引行数+2行
+	tableRowNo += len(indexList) + 2
+
+	// 生成列
+	if generatedColumnList := objInfo.GetGeneratedColumnList(); 0 < len(generatedColumnList) {
+		rowList := [][]string{}
+		for _, columnInfo := range generatedColumnList {
+			rowList = append(rowList, []string{columnInfo.ColumnName, columnInfo.GenerationExpression, map[bool]string{true: "stored", false: "virtual"}[columnInfo.IsStored]})
+		}
+		tableRowNo = renderingExcelSection(doc, sheetName, tableRowNo, "生成列/Generated", []string{"字段名\nField", "表达式\nExpression", "存储\nStored"}, rowList, tableStyle1)
+	}
+	// 表引擎
+	if "" != objInfo.Engine {
+		rowList := [][]string{{"Engine", objInfo.Engine}}
+		if "" != objInfo.PartitionKey {
+			rowList = append(rowList, []string{"Partition By", objInfo.PartitionKey})
+		}
+		if "" != objInfo.SortingKey {
+			rowList = append(rowList, []string{"Order By", objInfo.SortingKey})
+		}
+		if "" != objInfo.PrimaryKey {
+			rowList = append(rowList, []string{"Primary Key", objInfo.PrimaryKey})
+		}
+		if "" != objInfo.TTL {
+			rowList = append(rowList, []string{"TTL", objInfo.TTL})
+		}
+		tableRowNo = renderingExcelSection(doc, sheetName, tableRowNo, "引擎/Engine", []string{"属性\nProperty", "值\nValue"}, rowList, tableStyle1)
+	}
+	// 字符集及排序规则
+	if collationColumnList := objInfo.GetCollationColumnList(); "" != objInfo.CollationName || 0 < len(collationColumnList) {
+		rowList := [][]string{}
+		if "" != objInfo.CollationName {
+			rowList = append(rowList, []string{"(" + objInfo.TableName + ")", objInfo.CharacterSetName, objInfo.CollationName})
+		}
+		for _, columnInfo := range collationColumnList {
+			rowList = append(rowList, []string{columnInfo.ColumnName, columnInfo.CharacterSetName, columnInfo.CollationName})
+		}
+		tableRowNo = renderingExcelSection(doc, sheetName, tableRowNo, "排序规则/Collation", []string{"字段名\nField", "字符集\nCharset", "排序规则\nCollation"}, rowList, tableStyle1)
+	}
+	// 检查约束
+	if 0 < len(objInfo.CheckConstraintList) {
+		rowList := [][]string{}
+		for _, checkConstraintInfo := range objInfo.CheckConstraintList {
+			rowList = append(rowList, []string{checkConstraintInfo.ConstraintName, checkConstraintInfo.CheckClause})
+		}
+		tableRowNo = renderingExcelSection(doc, sheetName, tableRowNo, "检查约束/Check", []string{"约束名\nConstraint", "条件\nCondition"}, rowList, tableStyle1)
+	}
+	// 序列
+	if 0 < len(objInfo.SequenceList) {
+		tableRowNo = renderingExcelSection(doc, sheetName, tableRowNo, "序列/Sequence", excelSequenceHeaderList, excelSequenceRowList(objInfo.SequenceList), tableStyle1)
+	}
+	// 分区
+	if 0 < len(objInfo.PartitionList) {
+		rowList := [][]string{}
+		for _, partitionInfo := range objInfo.PartitionList {
+			rowList = append(rowList, []string{partitionInfo.PartitionName, partitionInfo.PartitionStrategy, partitionInfo.PartitionKey, partitionInfo.PartitionBound, fmt.Sprintf("%d", partitionInfo.RowEstimate)})
+		}
+		tableRowNo = renderingExcelSection(doc, sheetName, tableRowNo, "分区/Partition", []string{"分区名\nPartition", "方式\nStrategy", "分区键\nKey", "边界\nBound", "估计行数\nRows"}, rowList, tableStyle1)
+	}
+
+	// 视图定义及字段依赖
+	if "" != objInfo.ViewDefinition {
+		if err = renderingExcelView(doc, sheetName, objInfo, tableRowNo, tableStyle1); nil != err {
+			return err
+		}
+	}
+
 	// 当前为最后一个表时
 	if current == total {
 		// 移除模板页
@@ -422,6 +533,93 @@ func renderingExcelTable(dbConfig *configs.DatabaseConfig, templateData interfac
 	return nil
 }
 
+// renderingExcelView 渲染Excel视图定义及字段依赖
+func renderingExcelView(doc *excelize.File, sheetName string, objInfo *models.TableInfo, tableRowNo int, tableStyle int) error {
+	// SQL 样式（左上对齐，自动换行）
+	sqlStyle, err := doc.NewStyle(&excelize.Style{
+		Border: []excelize.Border{
+			{Type: "left", Color: "000000", Style: 1},
+			{Type: "top", Color: "000000", Style: 1},
+			{Type: "right", Color: "000000", Style: 1},
+			{Type: "bottom", Color: "000000", Style: 1},
+		},
+		Alignment: &excelize.Alignment{
+			Horizontal: "left",
+			Vertical:   "top",
+			WrapText:   true,
+		},
+	})
+	if nil != err {
+		return err
+	}
+
+	// 视图定义
+	doc.SetCellValue(sheetName, fmt.Sprintf("A%d", tableRowNo), "视图定义/View")
+	doc.SetCellValue(sheetName, fmt.Sprintf("B%d", tableRowNo), objInfo.ViewDefinition)
+	doc.MergeCell(sheetName, fmt.Sprintf("B%d", tableRowNo), fmt.Sprintf("J%d", tableRowNo))
+	doc.SetCellStyle(sheetName, fmt.Sprintf("B%d", tableRowNo), fmt.Sprintf("J%d", tableRowNo), sqlStyle)
+	// 按行数设置行高
+	doc.SetRowHeight(sheetName, tableRowNo, float64(strings.Count(objInfo.ViewDefinition, "\n")+1)*15)
+
+	// 往下移动2行
+	tableRowNo += 2
+
+	// 字段依赖
+	rowList := [][]string{}
+	for _, dependency := range objInfo.ViewDependencyList {
+		rowList = append(rowList, []string{dependency.ViewColumnName, dependency.TableName, dependency.ColumnName})
+	}
+	renderingExcelSection(doc, sheetName, tableRowNo, "依赖/Depends", []string{"视图字段\nView Field", "来源表\nSource Table", "来源字段\nSource Field"}, rowList, tableStyle)
+
+	return nil
+}
+
+// renderingExcelSection 渲染Excel附加区块（A列标题，B列起表头及数据），返回下一个可用行号
+func renderingExcelSection(doc *excelize.File, sheetName string, tableRowNo int, title string, headerList []string, rowList [][]string, tableStyle int) int {
+	// 标题
+	doc.SetCellValue(sheetName, fmt.Sprintf("A%d", tableRowNo), title)
+	// 表头
+	for col, header := range headerList {
+		cell, _ := excelize.CoordinatesToCellName(col+2, tableRowNo)
+		doc.SetCellValue(sheetName, cell, header)
+	}
+	// 数据
+	for idx, row := range rowList {
+		for col, value := range row {
+			cell, _ := excelize.CoordinatesToCellName(col+2, tableRowNo+idx+1)
+			doc.SetCellValue(sheetName, cell, value)
+		}
+	}
+	// 设置文字居中
+	endCell, _ := excelize.CoordinatesToCellName(len(headerList)+1, tableRowNo+len(rowList))
+	doc.SetCellStyle(sheetName, fmt.Sprintf("B%d", tableRowNo), endCell, tableStyle)
+
+	// 往下移动数据行数+2行
+	return tableRowNo + len(rowList) + 2
+}
+
+// excelSequenceRowList 序列区块数据
+func excelSequenceRowList(sequenceList []*models.SequenceInfo) [][]string {
+	rowList := [][]string{}
+	for _, sequenceInfo := range sequenceList {
+		ownerColumn := "-"
+		if "" != sequenceInfo.TableName {
+			ownerColumn = sequenceInfo.TableName + "." + sequenceInfo.ColumnName
+		}
+		rowList = append(rowList, []string{sequenceInfo.SequenceName, fmt.Sprintf("%d", sequenceInfo.StartValue), fmt.Sprintf("%d", sequenceInfo.IncrementBy), ownerColumn})
+	}
+	return rowList
+}
+
+// excelTypeRowList 自定义类型区块数据
+func excelTypeRowList(typeList []*models.TypeInfo) [][]string {
+	rowList := [][]string{}
+	for _, typeInfo := range typeList {
+		rowList = append(rowList, []string{typeInfo.SchemaName + "." + typeInfo.TypeName, typeInfo.TypeKind, typeInfo.TypeDefinition, typeInfo.Comment})
+	}
+	return rowList
+}
+
 // mkDir 创建目录
 func mkDir(outputDirPath string) (string, error) {
 	if _, err := os.Stat(outputDirPath); os.IsNotExist(err) {
@@ -0,0 +1,479 @@
+package services
+
+import (
+	"goDict/models"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// 词法单元类型
+const (
+	sqlTokenKeyword = iota // 关键字（未加引号的保留字）
+	sqlTokenIdent          // 标识符（可能带限定符，如 a.b.c）
+	sqlTokenString         // 字符串字面量
+	sqlTokenNumber         // 数字
+	sqlTokenSymbol         // 符号
+)
+
+// 视图解析时需要识别的保留字
+var sqlKeywordMap = map[string]bool{
+	"SELECT": true, "FROM": true, "WHERE": true, "AS": true, "ON": true, "USING": true,
+	"JOIN": true, "LEFT": true, "RIGHT": true, "INNER": true, "OUTER": true, "FULL": true, "CROSS": true, "NATURAL": true, "LATERAL": true,
+	"GROUP": true, "ORDER": true, "BY": true, "HAVING": true, "LIMIT": true, "OFFSET": true, "FETCH": true, "WINDOW": true,
+	"UNION": true, "INTERSECT": true, "EXCEPT": true, "MINUS": true, "ALL": true, "DISTINCT": true, "TOP": true, "WITH": true,
+	"CASE": true, "WHEN": true, "THEN": true, "ELSE": true, "END": true,
+	"AND": true, "OR": true, "NOT": true, "NULL": true, "IS": true, "IN": true, "LIKE": true, "BETWEEN": true, "EXISTS": true,
+	"TRUE": true, "FALSE": true, "ASC": true, "DESC": true, "OVER": true, "PARTITION": true, "INTERVAL": true,
+}
+
+// FROM 子句的结束关键字
+var sqlFromEndKeywordMap = map[string]bool{
+	"WHERE": true, "GROUP": true, "ORDER": true, "HAVING": true, "LIMIT": true, "OFFSET": true, "FETCH": true,
+	"WINDOW": true, "UNION": true, "INTERSECT": true, "EXCEPT": true, "MINUS": true,
+}
+
+// sqlToken SQL词法单元
+type sqlToken struct {
+	kind int
+	text string
+	// 标识符各段（仅标识符）
+	parts []string
+}
+
+// isKeyword 是否为指定关键字
+func (this *sqlToken) isKeyword(keyword string) bool {
+	return sqlTokenKeyword == this.kind && keyword == this.text
+}
+
+// isSymbol 是否为指定符号
+func (this *sqlToken) isSymbol(symbol string) bool {
+	return sqlTokenSymbol == this.kind && symbol == this.text
+}
+
+// isSimpleIdent 是否为不带限定符的标识符
+func (this *sqlToken) isSimpleIdent() bool {
+	return sqlTokenIdent == this.kind && 1 == len(this.parts)
+}
+
+// parseViewDependencyList 尽力解析视图定义，提取每个视图字段所依赖的基础表字段
+func parseViewDependencyList(definition string, tableColumnInfoMap map[string][]*models.ColumnInfo) []*models.ViewDependency {
+	return parseSelectDependencyList(tokenizeSql(definition), tableColumnInfoMap)
+}
+
+// parseSelectDependencyList 解析查询语句的词法单元，SELECT 列表中的标量子查询按子查询自身的 FROM 子句解析
+func parseSelectDependencyList(tokenList []*sqlToken, tableColumnInfoMap map[string][]*models.ColumnInfo) []*models.ViewDependency {
+	result := []*models.ViewDependency{}
+
+	// 找到最外层的 SELECT
+	start := -1
+	depth := 0
+	for idx, token := range tokenList {
+		if token.isSymbol("(") {
+			depth++
+		} else if token.isSymbol(")") {
+			depth--
+		} else if 0 == depth && token.isKeyword("SELECT") {
+			start = idx
+			break
+		}
+	}
+	if 0 > start {
+		return result
+	}
+
+	// 切分 SELECT 列表与 FROM 子句
+	selectTokenList := []*sqlToken{}
+	fromTokenList := []*sqlToken{}
+	inFrom := false
+	depth = 0
+	for _, token := range tokenList[start+1:] {
+		if 0 == depth {
+			if token.isKeyword("FROM") {
+				inFrom = true
+				continue
+			}
+			if token.isSymbol(";") || (inFrom && sqlTokenKeyword == token.kind && sqlFromEndKeywordMap[token.text]) {
+				break
+			}
+		}
+		if token.isSymbol("(") {
+			depth++
+		} else if token.isSymbol(")") {
+			depth--
+		}
+
+		if inFrom {
+			fromTokenList = append(fromTokenList, token)
+		} else {
+			selectTokenList = append(selectTokenList, token)
+		}
+	}
+
+	// 解析 FROM 子句
+	tableNameList, aliasMap := parseViewFromClause(fromTokenList, tableColumnInfoMap)
+
+	// 添加依赖（去重）
+	addDependency := func(viewColumnName string, tableName string, columnName string) {
+		for _, item := range result {
+			if item.ViewColumnName == viewColumnName && item.TableName == tableName && item.ColumnName == columnName {
+				return
+			}
+		}
+		result = append(result, &models.ViewDependency{
+			ViewColumnName: viewColumnName,
+			TableName:      tableName,
+			ColumnName:     columnName,
+		})
+	}
+
+	// 解析 SELECT 列表
+	for _, itemTokenList := range splitSqlTokenList(selectTokenList) {
+		// 去掉 DISTINCT / ALL / TOP n 等修饰
+		for 0 < len(itemTokenList) && (itemTokenList[0].isKeyword("DISTINCT") || itemTokenList[0].isKeyword("ALL")) {
+			itemTokenList = itemTokenList[1:]
+		}
+		if 2 < len(itemTokenList) && itemTokenList[0].isKeyword("TOP") {
+			itemTokenList = itemTokenList[2:]
+		}
+		if 1 > len(itemTokenList) {
+			continue
+		}
+
+		// 字段别名
+		alias := ""
+		body := itemTokenList
+		count := len(itemTokenList)
+		if 2 <= count && itemTokenList[count-1].isSimpleIdent() {
+			prev := itemTokenList[count-2]
+			if prev.isKeyword("AS") {
+				alias = itemTokenList[count-1].parts[0]
+				body = itemTokenList[:count-2]
+			} else if sqlTokenIdent == prev.kind || sqlTokenString == prev.kind || sqlTokenNumber == prev.kind || prev.isSymbol(")") || prev.isKeyword("END") {
+				alias = itemTokenList[count-1].parts[0]
+				body = itemTokenList[:count-1]
+			}
+		}
+
+		// 全部字段：* 或 t.*
+		if 1 == len(body) && (body[0].isSymbol("*") || (sqlTokenIdent == body[0].kind && "*" == body[0].parts[len(body[0].parts)-1])) {
+			starTableNameList := tableNameList
+			if sqlTokenIdent == body[0].kind {
+				starTableNameList = []string{aliasMap[strings.ToLower(body[0].parts[len(body[0].parts)-2])]}
+			}
+			for _, tableName := range starTableNameList {
+				for _, columnInfo := range tableColumnInfoMap[tableName] {
+					addDependency(columnInfo.ColumnName, tableName, columnInfo.ColumnName)
+				}
+			}
+			continue
+		}
+
+		// 视图字段名：别名 > 字段名 > 表达式
+		viewColumnName := alias
+		if "" == viewColumnName {
+			if 1 == len(body) && sqlTokenIdent == body[0].kind {
+				viewColumnName = body[0].parts[len(body[0].parts)-1]
+			} else {
+				viewColumnName = joinSqlTokenList(body)
+			}
+		}
+
+		// 提取表达式中引用的字段
+		for idx := 0; idx < len(body); idx++ {
+			token := body[idx]
+			// 标量子查询，如 (SELECT max(o.amount) FROM orders o WHERE ...)
+			if token.isSymbol("(") && idx+1 < len(body) && body[idx+1].isKeyword("SELECT") {
+				end := findClosingParen(body, idx)
+				for _, dependency := range parseSelectDependencyList(body[idx+1:end], tableColumnInfoMap) {
+					addDependency(viewColumnName, dependency.TableName, dependency.ColumnName)
+				}
+				idx = end
+				continue
+			}
+			if sqlTokenIdent != token.kind {
+				continue
+			}
+			// 函数名
+			if idx+1 < len(body) && body[idx+1].isSymbol("(") {
+				continue
+			}
+			// CAST(x AS type) 中的类型名
+			if 0 < idx && body[idx-1].isKeyword("AS") {
+				continue
+			}
+
+			tableName, columnName := resolveViewColumn(token.parts, tableNameList, aliasMap, tableColumnInfoMap)
+			if "" == tableName || "" == columnName {
+				continue
+			}
+			addDependency(viewColumnName, tableName, columnName)
+		}
+	}
+
+	return result
+}
+
+// parseViewFromClause 解析 FROM 子句，返回基础表列表以及别名map（key为小写别名）
+func parseViewFromClause(tokenList []*sqlToken, tableColumnInfoMap map[string][]*models.ColumnInfo) ([]string, map[string]string) {
+	tableNameList := []string{}
+	aliasMap := map[string]string{}
+
+	expectTable := true
+	for idx := 0; idx < len(tokenList); idx++ {
+		token := tokenList[idx]
+
+		switch {
+		case token.isSymbol("(") && expectTable && idx+1 < len(tokenList) && !tokenList[idx+1].isKeyword("SELECT"):
+			// 连接分组，如 MySQL 的 from (a join b on ...)
+			continue
+		case token.isSymbol("("):
+			// 跳过括号内容（子查询、ON 条件等）
+			depth := 0
+			for ; idx < len(tokenList); idx++ {
+				if tokenList[idx].isSymbol("(") {
+					depth++
+				} else if tokenList[idx].isSymbol(")") {
+					depth--
+					if 0 == depth {
+						break
+					}
+				}
+			}
+			// 派生表的别名无法追溯到基础表
+			if expectTable {
+				if idx+1 < len(tokenList) && tokenList[idx+1].isKeyword("AS") {
+					idx++
+				}
+				if idx+1 < len(tokenList) && tokenList[idx+1].isSimpleIdent() {
+					idx++
+					aliasMap[strings.ToLower(tokenList[idx].parts[0])] = ""
+				}
+				expectTable = false
+			}
+		case token.isSymbol(","), token.isKeyword("JOIN"):
+			expectTable = true
+		case token.isKeyword("ON"), token.isKeyword("USING"):
+			expectTable = false
+		case sqlTokenIdent == token.kind && expectTable:
+			// 表名（忽略库名/模式名）
+			tableName := findTableName(token.parts[len(token.parts)-1], tableColumnInfoMap)
+			alias := tableName
+			if idx+2 < len(tokenList) && tokenList[idx+1].isKeyword("AS") && tokenList[idx+2].isSimpleIdent() {
+				alias = tokenList[idx+2].parts[0]
+				idx += 2
+			} else if idx+1 < len(tokenList) && tokenList[idx+1].isSimpleIdent() {
+				alias = tokenList[idx+1].parts[0]
+				idx++
+			}
+
+			if !slices.Contains(tableNameList, tableName) {
+				tableNameList = append(tableNameList, tableName)
+			}
+			aliasMap[strings.ToLower(alias)] = tableName
+			aliasMap[strings.ToLower(tableName)] = tableName
+			expectTable = false
+		}
+	}
+
+	return tableNameList, aliasMap
+}
+
+// resolveViewColumn 将字段引用解析为 基础表名 + 字段名
+func resolveViewColumn(parts []string, tableNameList []string, aliasMap map[string]string, tableColumnInfoMap map[string][]*models.ColumnInfo) (string, string) {
+	columnName := parts[len(parts)-1]
+
+	// 带限定符：alias.column / schema.table.column
+	if 1 < len(parts) {
+		qualifier := strings.ToLower(parts[len(parts)-2])
+		tableName, ok := aliasMap[qualifier]
+		if !ok {
+			tableName = findTableName(parts[len(parts)-2], tableColumnInfoMap)
+		}
+		if "" == tableName {
+			return "", ""
+		}
+		return tableName, findColumnName(tableName, columnName, tableColumnInfoMap)
+	}
+
+	// 不带限定符：在 FROM 的表中查找包含该字段的表
+	candidateList := []string{}
+	for _, tableName := range tableNameList {
+		if "" != findColumnName(tableName, columnName, tableColumnInfoMap) {
+			candidateList = append(candidateList, tableName)
+		}
+	}
+	if 1 == len(candidateList) {
+		return candidateList[0], findColumnName(candidateList[0], columnName, tableColumnInfoMap)
+	}
+
+	return "", ""
+}
+
+// findTableName 按名称（忽略大小写）查找已知表名，找不到时原样返回
+func findTableName(tableName string, tableColumnInfoMap map[string][]*models.ColumnInfo) string {
+	if _, ok := tableColumnInfoMap[tableName]; ok {
+		return tableName
+	}
+	for name := range tableColumnInfoMap {
+		if strings.EqualFold(name, tableName) {
+			return name
+		}
+	}
+	return tableName
+}
+
+// findColumnName 按名称（忽略大小写）查找表中的字段名，找不到时返回空字符串
+func findColumnName(tableName string, columnName string, tableColumnInfoMap map[string][]*models.ColumnInfo) string {
+	for _, columnInfo := range tableColumnInfoMap[tableName] {
+		if strings.EqualFold(columnInfo.ColumnName, columnName) {
+			return columnInfo.ColumnName
+		}
+	}
+	return ""
+}
+
+// findClosingParen 与 start 处左括号匹配的右括号位置，没有时为末尾
+func findClosingParen(tokenList []*sqlToken, start int) int {
+	depth := 0
+	for idx := start; idx < len(tokenList); idx++ {
+		if tokenList[idx].isSymbol("(") {
+			depth++
+		} else if tokenList[idx].isSymbol(")") {
+			depth--
+			if 0 == depth {
+				return idx
+			}
+		}
+	}
+	return len(tokenList)
+}
+
+// splitSqlTokenList 按最外层逗号切分
+func splitSqlTokenList(tokenList []*sqlToken) [][]*sqlToken {
+	result := [][]*sqlToken{}
+
+	depth := 0
+	current := []*sqlToken{}
+	for _, token := range tokenList {
+		if token.isSymbol("(") {
+			depth++
+		} else if token.isSymbol(")") {
+			depth--
+		} else if 0 == depth && token.isSymbol(",") {
+			result = append(result, current)
+			current = []*sqlToken{}
+			continue
+		}
+		current = append(current, token)
+	}
+	if 0 < len(current) {
+		result = append(result, current)
+	}
+
+	return result
+}
+
+// joinSqlTokenList 将词法单元还原为文本
+func joinSqlTokenList(tokenList []*sqlToken) string {
+	var builder strings.Builder
+	for idx, token := range tokenList {
+		if 0 < idx && !token.isSymbol("(") && !token.isSymbol(")") && !tokenList[idx-1].isSymbol("(") {
+			builder.WriteString(" ")
+		}
+		builder.WriteString(token.text)
+	}
+	return builder.String()
+}
+
+// tokenizeSql SQL词法分析（合并带限定符的标识符）
+func tokenizeSql(sql string) []*sqlToken {
+	result := []*sqlToken{}
+	runeList := []rune(sql)
+	length := len(runeList)
+
+	// 读取到指定结束符为止的内容（支持双写转义）
+	readQuoted := func(idx int, end rune) (string, int) {
+		var builder strings.Builder
+		for idx++; idx < length; idx++ {
+			if end == runeList[idx] {
+				if idx+1 < length && end == runeList[idx+1] {
+					builder.WriteRune(end)
+					idx++
+					continue
+				}
+				break
+			}
+			builder.WriteRune(runeList[idx])
+		}
+		return builder.String(), idx
+	}
+	isWordRune := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || '_' == r || '$' == r || '#' == r
+	}
+
+	// 当前标识符是否可与下一段合并（a . b）
+	appendIdent := func(part string, raw string) {
+		if 2 <= len(result) && result[len(result)-1].isSymbol(".") && sqlTokenIdent == result[len(result)-2].kind {
+			prev := result[len(result)-2]
+			prev.parts = append(prev.parts, part)
+			prev.text += "." + raw
+			result = result[:len(result)-1]
+			return
+		}
+		result = append(result, &sqlToken{kind: sqlTokenIdent, text: raw, parts: []string{part}})
+	}
+
+	for idx := 0; idx < length; idx++ {
+		r := runeList[idx]
+		switch {
+		case unicode.IsSpace(r):
+			continue
+		case '-' == r && idx+1 < length && '-' == runeList[idx+1]:
+			// 单行注释
+			for idx < length && '\n' != runeList[idx] {
+				idx++
+			}
+		case '/' == r && idx+1 < length && '*' == runeList[idx+1]:
+			// 多行注释
+			for idx += 2; idx+1 < length && !('*' == runeList[idx] && '/' == runeList[idx+1]); idx++ {
+			}
+			idx++
+		case '\'' == r:
+			text, end := readQuoted(idx, '\'')
+			result = append(result, &sqlToken{kind: sqlTokenString, text: "'" + text + "'"})
+			idx = end
+		case '"' == r || '`' == r:
+			text, end := readQuoted(idx, r)
+			appendIdent(text, string(r)+text+string(r))
+			idx = end
+		case '[' == r:
+			text, end := readQuoted(idx, ']')
+			appendIdent(text, "["+text+"]")
+			idx = end
+		case isWordRune(r):
+			start := idx
+			for idx+1 < length && isWordRune(runeList[idx+1]) {
+				idx++
+			}
+			word := string(runeList[start : idx+1])
+			upper := strings.ToUpper(word)
+			// 限定符后的单词一律视为标识符
+			qualified := 0 < len(result) && result[len(result)-1].isSymbol(".")
+			if unicode.IsDigit(r) {
+				result = append(result, &sqlToken{kind: sqlTokenNumber, text: word})
+			} else if sqlKeywordMap[upper] && !qualified {
+				result = append(result, &sqlToken{kind: sqlTokenKeyword, text: upper})
+			} else {
+				appendIdent(word, word)
+			}
+		case '*' == r && 2 <= len(result) && result[len(result)-1].isSymbol(".") && sqlTokenIdent == result[len(result)-2].kind:
+			// t.*
+			appendIdent("*", "*")
+		default:
+			result = append(result, &sqlToken{kind: sqlTokenSymbol, text: string(r)})
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"goDict/models"
+	"slices"
+	"testing"
+)
+
+func TestParseViewDependencyList(t *testing.T) {
+	tableColumnInfoMap := map[string][]*models.ColumnInfo{}
+	for tableName, columnNameList := range map[string][]string{"users": {"id", "name", "email"}, "orders": {"id", "user_id", "amount", "status"}} {
+		for _, columnName := range columnNameList {
+			tableColumnInfoMap[tableName] = append(tableColumnInfoMap[tableName], &models.ColumnInfo{TableName: tableName, ColumnName: columnName})
+		}
+	}
+
+	// 依赖的文本形式为 视图字段:表.字段
+	testCaseList := []struct {
+		name       string
+		definition string
+		want       []string
+	}{
+		{"alias", "SELECT u.id AS user_id, u.name FROM users u",
+			[]string{"user_id:users.id", "name:users.name"}},
+		{"alias without as", "select o.amount total from orders as o",
+			[]string{"total:orders.amount"}},
+		{"star", "SELECT * FROM users",
+			[]string{"id:users.id", "name:users.name", "email:users.email"}},
+		{"qualified star", "SELECT u.*, o.amount FROM users u JOIN orders o ON o.user_id = u.id",
+			[]string{"id:users.id", "name:users.name", "email:users.email", "amount:orders.amount"}},
+		// 不带限定符的字段在唯一包含它的表中查找，id 有歧义
+		{"join", "SELECT name, amount, id FROM users LEFT OUTER JOIN orders ON orders.user_id = users.id",
+			[]string{"name:users.name", "amount:orders.amount"}},
+		// 派生表无法追溯到基础表
+		{"derived table", "SELECT t.cnt, u.name FROM users u JOIN (SELECT user_id, count(*) cnt FROM orders GROUP BY user_id) t ON t.user_id = u.id",
+			[]string{"name:users.name"}},
+		{"scalar subquery", "SELECT u.name, (SELECT max(o.amount) FROM orders o WHERE o.user_id = u.id) AS max_amount FROM users u",
+			[]string{"name:users.name", "max_amount:orders.amount"}},
+		{"double-quoted and bracketed", `SELECT "u"."name" AS "User Name", [o].[amount] FROM "public"."users" "u", [orders] [o]`,
+			[]string{"User Name:users.name", "amount:orders.amount"}},
+		{"back-quoted", "SELECT `u`.`email` FROM `shop`.`users` AS `u`",
+			[]string{"email:users.email"}},
+		{"case-insensitive names", "SELECT U.NAME FROM USERS U",
+			[]string{"NAME:users.name"}},
+		{"comments", "SELECT /* o.amount, */ u.name -- , o.status\n FROM users u",
+			[]string{"name:users.name"}},
+		{"string literal with keywords", "SELECT 'select * from orders' AS note, u.name FROM users u WHERE u.name <> 'from'",
+			[]string{"name:users.name"}},
+		{"escaped quote", "SELECT it.name FROM users it WHERE it.email LIKE '%''from''%'",
+			[]string{"name:users.name"}},
+		{"case", "SELECT CASE WHEN o.status = 'FROM' THEN o.amount ELSE 0 END AS paid FROM orders o",
+			[]string{"paid:orders.status", "paid:orders.amount"}},
+		{"function and cast", "SELECT CAST(o.amount AS decimal) AS amt, upper(u.name) FROM orders o, users u",
+			[]string{"amt:orders.amount", "upper(u.name):users.name"}},
+		{"create view", "CREATE VIEW v AS SELECT DISTINCT id FROM users",
+			[]string{"id:users.id"}},
+		{"top", "SELECT TOP 10 u.name FROM users u",
+			[]string{"name:users.name"}},
+		// 只解析第一个查询
+		{"union", "SELECT name FROM users UNION SELECT status FROM orders",
+			[]string{"name:users.name"}},
+		{"not a query", "CREATE VIEW v", []string{}},
+	}
+	for _, testCase := range testCaseList {
+		dependencyList := []string{}
+		for _, dependency := range parseViewDependencyList(testCase.definition, tableColumnInfoMap) {
+			dependencyList = append(dependencyList, dependency.ViewColumnName+":"+dependency.TableName+"."+dependency.ColumnName)
+		}
+		if !slices.Equal(testCase.want, dependencyList) {
+			t.Errorf("%s: %q\n got  %q\n want %q", testCase.name, testCase.definition, dependencyList, testCase.want)
+		}
+	}
+}
+
+func TestTokenizeSql(t *testing.T) {
+	testCaseList := []struct {
+		sql  string
+		want []string
+	}{
+		{"select a.b.c, t.* from x", []string{"SELECT", "a.b.c", ",", "t.*", "FROM", "x"}},
+		// 限定符后的关键字为标识符
+		{"select t.from, t.order from t", []string{"SELECT", "t.from", ",", "t.order", "FROM", "t"}},
+		{`select "a ""b""", [c d], ` + "`e`" + ` from t`, []string{"SELECT", `"a "b""`, ",", "[c d]", ",", "`e`", "FROM", "t"}},
+		{"select 'it''s -- not /* a comment */' from t", []string{"SELECT", "'it's -- not /* a comment */'", "FROM", "t"}},
+		{"select a -- comment\n, /* block\n comment */ b from t", []string{"SELECT", "a", ",", "b", "FROM", "t"}},
+	}
+	for _, testCase := range testCaseList {
+		textList := []string{}
+		for _, token := range tokenizeSql(testCase.sql) {
+			textList = append(textList, token.text)
+		}
+		if !slices.Equal(testCase.want, textList) {
+			t.Errorf("tokenizeSql(%q)\n got  %q\n want %q", testCase.sql, textList, testCase.want)
+		}
+	}
+}
@@ -2,17 +2,19 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"goDict/configs"
+	"goDict/models"
 	"gorm.io/gen"
 	"gorm.io/gorm"
-	"io"
 	"log/slog"
-	"os"
 	"path"
+	"path/filepath"
 	"reflect"
+	"regexp"
 	"strings"
-	"text/template"
 )
 
 type TemplateData struct {
@@ -21,6 +23,18 @@ type TemplateData struct {
 	LowerModelName string
 	ModelDirPath   string
 	ServiceDirPath string
+	// 生成选项
+	Option *GenerateOption
+	// 表名
+	TableName string
+	// 表的完整元数据（字段、索引、注释等），未读取到时为 nil
+	Table *models.TableInfo
+	// 数据库方言，如 mysql、postgres
+	Dialect string
+	// 数据类型映射，生成 TypeScript、Java、proto 类型时使用
+	TypeMapping TypeMapping
+	// proto 字段编号：列名 => 编号，含已删除的列
+	ProtoFieldNumberMap map[string]int
 }
 
 func NewTemplateData(snakeModelName string, upperModelName string, lowerModelName string, modelDirPathString string, serviceDirPathString string) *TemplateData {
@@ -40,6 +54,110 @@ func (this *TemplateData) GetServiceFilePath() string {
 	return path.Join(this.ModelDirPath, fmt.Sprintf("%s%s", this.UpperModelName, "Service.gen.go"))
 }
 
+// Java 包名，如 com.acme.entity
+var javaPackageNameRegexp = regexp.MustCompile(`^[A-Za-z_$][\w$]*(\.[A-Za-z_$][\w$]*)*$`)
+
+// GenerateOption 代码生成选项，各目录相对输出根目录，包名取目录的最后一级
+type GenerateOption struct {
+	// 输出根目录
+	OutputDirPath string
+	// Go 模块路径（输出根目录对应的导入路径）
+	ModulePath string
+	// gorm/gen 查询代码目录
+	QueryPackage string
+	// 模型目录
+	ModelPackage string
+	// 服务目录
+	ServicePackage string
+	// BaseDao 所在目录
+	DaoPackage string
+	// HTTP 处理器目录，为空时不生成
+	HandlerPackage string
+	// TypeScript 接口目录，为空时不生成
+	TypeScriptPackage string
+	// Java 源码根目录，为空时不生成 Java 实体
+	JavaPackage string
+	// Java 实体的包名，如 com.acme.entity
+	JavaPackageName string
+	// proto 文件目录，为空时不生成
+	ProtoPackage string
+	// proto 的包名，如 acme.entity
+	ProtoPackageName string
+	// 用户模板目录，其中的同名模板覆盖内置模板
+	TemplateDirPath string
+	// 生成的表，为空时生成全部表
+	TableNameList []string
+}
+
+// NewGenerateOption 创建代码生成选项，目录使用本项目的默认结构
+func NewGenerateOption(outputDirPath string, modulePath string) *GenerateOption {
+	return &GenerateOption{
+		OutputDirPath:     outputDirPath,
+		ModulePath:        modulePath,
+		QueryPackage:      "dao/generate",
+		ModelPackage:      "models",
+		ServicePackage:    "services",
+		DaoPackage:        "dao",
+		HandlerPackage:    "handlers",
+		TypeScriptPackage: "web/types",
+		JavaPackage:       "java",
+		JavaPackageName:   "entity",
+		ProtoPackage:      "proto",
+		ProtoPackageName:  "entity",
+		TemplateDirPath:   DefaultTemplateDirPath(),
+	}
+}
+
+// Validate 校验选项
+func (this *GenerateOption) Validate() error {
+	if "" == strings.TrimSpace(this.OutputDirPath) {
+		return errors.New("输出目录不能为空")
+	}
+	if "" == strings.TrimSpace(this.ModulePath) {
+		return errors.New("模块路径不能为空")
+	}
+	for _, pkg := range []string{this.QueryPackage, this.ModelPackage, this.ServicePackage, this.DaoPackage} {
+		if "" == strings.TrimSpace(pkg) || path.IsAbs(pkg) || strings.HasPrefix(path.Clean(pkg), "..") {
+			return fmt.Errorf("无效的包目录: %q", pkg)
+		}
+	}
+	// 基础代码按包输出（如 query_filter.gen.go 在模型、DAO 目录中都有），目录不能相同
+	if path.Clean(this.ModelPackage) == path.Clean(this.DaoPackage) || path.Clean(this.ModelPackage) == path.Clean(this.ServicePackage) || path.Clean(this.DaoPackage) == path.Clean(this.ServicePackage) {
+		return errors.New("模型、DAO、服务的包目录不能相同")
+	}
+	// 可选的目录
+	for _, pkg := range []string{this.HandlerPackage, this.TypeScriptPackage, this.JavaPackage, this.ProtoPackage} {
+		if "" != pkg && (path.IsAbs(pkg) || strings.HasPrefix(path.Clean(pkg), "..")) {
+			return fmt.Errorf("无效的包目录: %q", pkg)
+		}
+	}
+	if "" != this.JavaPackage && !javaPackageNameRegexp.MatchString(this.JavaPackageName) {
+		return fmt.Errorf("无效的 Java 包名: %q", this.JavaPackageName)
+	}
+	if "" != this.ProtoPackage && !protoPackageNameRegexp.MatchString(this.ProtoPackageName) {
+		return fmt.Errorf("无效的 proto 包名: %q", this.ProtoPackageName)
+	}
+	return nil
+}
+
+// GetDirPath 包目录的完整路径
+func (this *GenerateOption) GetDirPath(pkg string) string {
+	return filepath.Join(this.OutputDirPath, filepath.FromSlash(pkg))
+}
+
+// GetJavaSourcePackage Java 实体目录，即源码根目录下包名对应的目录，未设置源码根目录时为空
+func (this *GenerateOption) GetJavaSourcePackage() string {
+	if "" == this.JavaPackage {
+		return ""
+	}
+	return path.Join(this.JavaPackage, strings.ReplaceAll(this.JavaPackageName, ".", "/"))
+}
+
+// GetImportPath 包的导入路径
+func (this *GenerateOption) GetImportPath(pkg string) string {
+	return path.Join(this.ModulePath, pkg)
+}
+
 type GeneratorService struct {
 	db *gorm.DB
 }
@@ -50,30 +168,89 @@ func NewGeneratorService(db *gorm.DB) *GeneratorService {
 	}
 }
 
-func (gs *GeneratorService) GenerateModels(outPath, modelDirPath string, serviceDirPath string) (modelArray []interface{}) {
+// GenerateModels 生成模型及其依赖的 BaseModel、BaseDao、BaseService，并按模板生成每个模型的 DAO、服务、测试、HTTP 处理器、TypeScript 接口、Java 实体及 proto 消息，返回按模板生成的文件列表；
+// ctx 取消时在当前模型完成后停止并返回 ctx 的错误，已生成的文件保留（可能覆盖了原有文件，不删除）
+func (gs *GeneratorService) GenerateModels(ctx context.Context, dbConfig *configs.DatabaseConfig, option *GenerateOption, progress ProgressFunc) (pathList []string, err error) {
+	if err = option.Validate(); nil != err {
+		return nil, err
+	}
+	// gorm/gen 以目录中是否含路径分隔符区分包名与路径，统一使用绝对路径（不修改调用方的选项）
+	resolvedOption := *option
+	option = &resolvedOption
+	option.OutputDirPath, err = filepath.Abs(option.OutputDirPath)
+	if nil != err {
+		return nil, err
+	}
+
+	// 读取表的完整元数据，供模板使用
+	databaseInfo, err := NewDbDictService(gs.db).GetDatabaseInfo(ctx, dbConfig, option.TableNameList, progress)
+	if nil != err {
+		return nil, err
+	}
+
+	// 数据类型映射
+	typeMapping, err := loadTypeMapping(option.TemplateDirPath)
+	if nil != err {
+		return nil, err
+	}
+
+	// proto 字段编号锁定文件
+	var protoFieldNumberLock ProtoFieldNumberLock
+	protoFieldNumberLockPath := filepath.Join(option.GetDirPath(option.ProtoPackage), protoFieldNumberLockFileName)
+	if "" != option.ProtoPackage {
+		if protoFieldNumberLock, err = loadProtoFieldNumberLock(protoFieldNumberLockPath); nil != err {
+			return nil, err
+		}
+	}
+
+	// gorm/gen 出错时 panic，转为错误返回
+	defer func() {
+		if r := recover(); nil != r {
+			err = fmt.Errorf("生成模型失败: %v", r)
+			// 取消导致的失败保留 ctx 的错误，便于调用方识别
+			if ctxErr := ctx.Err(); nil != ctxErr {
+				err = fmt.Errorf("生成模型失败: %w", ctxErr)
+			}
+		}
+	}()
+
 	// 初始化GORM Gen生成器
 	g := gen.NewGenerator(gen.Config{
-		OutPath:       outPath,
-		ModelPkgPath:  modelDirPath,
+		OutPath:       option.GetDirPath(option.QueryPackage),
+		ModelPkgPath:  option.GetDirPath(option.ModelPackage),
 		Mode:          gen.WithDefaultQuery | gen.WithQueryInterface,
 		FieldNullable: true,
 	})
 
-	g.UseDB(gs.db)
+	g.UseDB(gs.db.WithContext(ctx))
 
-	// 为每个表生成模型配置
-	modelArray = g.GenerateAllTable()
+	// 为选中的表生成模型配置
+	var modelArray []interface{}
+	if 0 < len(option.TableNameList) {
+		for _, tableName := range option.TableNameList {
+			modelArray = append(modelArray, g.GenerateModel(tableName))
+		}
+	} else {
+		modelArray = g.GenerateAllTable()
+	}
 
 	// 执行生成
 	g.Execute()
+	if err = ctx.Err(); nil != err {
+		return nil, err
+	}
 
 	// 生成模板
 	if 1 > len(modelArray) {
-		return modelArray
+		return nil, nil
 	}
 
+	serviceDirPath := option.GetDirPath(option.ServicePackage)
 	// 志哥生成模板文件
-	for _, model := range modelArray {
+	for i, model := range modelArray {
+		if err = ctx.Err(); nil != err {
+			return pathList, err
+		}
 		// 获取 model 的实际值（通过反射）
 		v := reflect.ValueOf(model).Elem()
 		if v.Kind() == reflect.Struct {
@@ -81,24 +258,63 @@ func (gs *GeneratorService) GenerateModels(outPath, modelDirPath string, service
 			fileNameField := v.FieldByName("FileName")
 			modelStructField := v.FieldByName("ModelStructName")
 			queryStructField := v.FieldByName("QueryStructName")
+			tableNameField := v.FieldByName("TableName")
 
-			if !gs.isValidField(fileNameField, modelStructField, queryStructField) {
+			if !gs.isValidField(fileNameField, modelStructField, queryStructField, tableNameField) {
 				continue
 			}
 
 			// 创建模板数据
-			templateData := NewTemplateData(fileNameField.String(), modelStructField.String(), queryStructField.String(), modelDirPath, serviceDirPath)
+			templateData := NewTemplateData(fileNameField.String(), modelStructField.String(), queryStructField.String(), option.GetDirPath(option.ModelPackage), serviceDirPath)
+			templateData.Option = option
+			templateData.TableName = tableNameField.String()
+			templateData.Table = findTableInfo(databaseInfo, templateData.TableName)
+			templateData.Dialect = gs.db.Dialector.Name()
+			templateData.TypeMapping = typeMapping
+			if nil != protoFieldNumberLock && nil != templateData.Table {
+				templateData.ProtoFieldNumberMap = protoFieldNumberLock.Assign(templateData.Table)
+			}
+
+			progress.report(ProgressPhaseCode, i+1, len(modelArray), templateData.TableName)
 
 			// 处理 model
-			gs.postProcessModel(templateData)
+			if _, err = gs.postProcessModel(templateData); nil != err {
+				return pathList, err
+			}
 
-			// 根据模板生成service
-			serviceFilePath := gs.GenerateService(templateData)
-			slog.Info("生成services文件名: " + serviceFilePath)
+			// 根据模板生成代码
+			codePathList, err := gs.GenerateCode(templateData)
+			pathList = append(pathList, codePathList...)
+			if nil != err {
+				return pathList, err
+			}
+			slog.Info("生成代码", "model", templateData.UpperModelName, "pathList", codePathList)
 		}
 	}
 
-	return modelArray
+	// 生成模型、DAO、服务依赖的基础代码
+	basePathList, err := gs.GenerateBaseCode(option)
+	pathList = append(pathList, basePathList...)
+	if nil != err {
+		return pathList, err
+	}
+
+	// 生成各模型共用的代码
+	commonPathList, err := gs.GenerateCommonCode(option)
+	pathList = append(pathList, commonPathList...)
+	if nil != err {
+		return pathList, err
+	}
+
+	// 保存 proto 字段编号，下次生成时沿用
+	if nil != protoFieldNumberLock {
+		if err = protoFieldNumberLock.Save(protoFieldNumberLockPath); nil != err {
+			return pathList, err
+		}
+		pathList = append(pathList, protoFieldNumberLockPath)
+	}
+
+	return pathList, nil
 }
 
 // isValidField 判断字段是否有效
@@ -112,108 +328,3 @@ func (gs *GeneratorService) isValidField(fieldArray ...reflect.Value) bool {
 	}
 	return true
 }
-
-// postProcessModel 处理model文件
-func (gs *GeneratorService) postProcessModel(templateData *TemplateData) (string, error) {
-	// Args
-	if nil == templateData {
-		return "", errors.New("无效的模板数据")
-	}
-
-	// model文件路径
-	modelPath := templateData.GetModelFilePath()
-	// 获取文件权限
-	info, err := os.Stat(modelPath)
-	if err != nil {
-		return "", err
-	}
-	// 加载生成的model文件
-	file, err := os.Open(modelPath)
-	// 文件权限
-	fileMode := info.Mode().Perm()
-	defer file.Close()
-
-	// 读取全部内容
-	fileContent, err := io.ReadAll(file)
-	if err != nil {
-		return "", err
-	}
-
-	// 处理换行符
-	content := strings.ReplaceAll(strings.ReplaceAll(string(fileContent), "\r", "\n"), "\n\n", "\n")
-
-	// 转为行
-	lineArray := strings.Split(content, "\n")
-	newLineArray := []string{}
-	// 遍历行
-	for _, line := range lineArray {
-		// 忽略空行
-		if 1 > len(strings.TrimSpace(line)) {
-			continue
-		}
-
-		// 在TableName()方法处，改为值引用而不是指针
-		if strings.Contains(line, " TableName() string") {
-			line = strings.ReplaceAll(line, "func (*", "func (")
-		}
-		// 添加当前行
-		newLineArray = append(newLineArray, line)
-
-		// 在 type 后添继承
-		if strings.HasPrefix(line, "type") {
-			newLineArray = append(newLineArray, "\tBaseModel")
-		}
-	}
-	// 更新内容
-	content = strings.Join(newLineArray, "\n")
-
-	// 写入文件
-	err = os.WriteFile(modelPath, []byte(content), fileMode)
-	if err != nil {
-		return "", err
-	}
-
-	return content, nil
-}
-
-func (gs *GeneratorService) GenerateService(templateData *TemplateData) string {
-	// 模板数据
-	templateDataString := map[string]string{
-		// 变量名从templateData改为data，以匹配Execute方法
-		"UpperModelName": templateData.UpperModelName,
-		"lowerModelName": templateData.LowerModelName,
-	}
-
-	// 1. 打开模板文件
-	fileContent, err := os.ReadFile("./resources/templates/gen_template.go")
-	if err != nil {
-		panic(err)
-	}
-
-	// 2. 转换为string
-	content := strings.ReplaceAll(strings.ReplaceAll(string(fileContent), "\r", "\n"), "\n\n", "\n")
-
-	// 3. 跳过前两行（包声明和导入）
-	lines := strings.Split(content, "\n")
-	// 从第3行开始（索引为2）拼接剩余内容
-	filteredContent := strings.Join(lines[2:], "\n")
-
-	// 4. 创建模板并解析
-	t := template.Must(template.New("gen_template").Parse(filteredContent))
-
-	// 5. 创建输出文件
-	savePath := fmt.Sprintf("%s/%s_service.gen.go", templateData.ServiceDirPath, templateData.SnakeModelName)
-	outFile, err := os.Create(savePath)
-	if err != nil {
-		panic(err)
-	}
-	defer outFile.Close()
-
-	// 6. 执行模板生成
-	err = t.Execute(outFile, templateDataString)
-	if err != nil {
-		panic(err)
-	}
-
-	return savePath
-}
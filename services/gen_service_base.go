@@ -0,0 +1,107 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"goDict/dao"
+	"goDict/models"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// baseCode 生成的模型、DAO、服务依赖的基础代码（BaseModel、BaseDao、BaseService 等），取自 GenDict 自身的源码
+type baseCode struct {
+	// 源码
+	sourceFS fs.FS
+	// 源码所在包的导入路径
+	importPath string
+	// 输出目录
+	getPackage func(option *GenerateOption) string
+}
+
+// baseCodeList 输出到目标项目的基础代码，生成的模型嵌入 BaseModel，DAO、服务分别嵌入同包的 BaseDao、BaseService
+var baseCodeList = []baseCode{
+	{models.SourceFS, reflect.TypeOf(models.BaseModel{}).PkgPath(), func(option *GenerateOption) string { return option.ModelPackage }},
+	{dao.SourceFS, reflect.TypeOf(dao.BaseDao[models.BaseModel]{}).PkgPath(), func(option *GenerateOption) string { return option.DaoPackage }},
+	{baseServiceSourceFS, reflect.TypeOf(BaseService[models.BaseModel]{}).PkgPath(), func(option *GenerateOption) string { return option.ServicePackage }},
+}
+
+// GenerateBaseCode 将基础代码输出到模型、DAO、服务目录（文件名为 *.gen.go），包名及导入路径改为目标项目的；
+// 目标即 GenDict 自身的包时不生成。返回生成的文件列表
+func (gs *GeneratorService) GenerateBaseCode(option *GenerateOption) ([]string, error) {
+	// GenDict 的包 => 目标项目的包
+	importPathMap := map[string]string{}
+	for _, code := range baseCodeList {
+		importPathMap[code.importPath] = option.GetImportPath(code.getPackage(option))
+	}
+
+	pathList := []string{}
+	for _, code := range baseCodeList {
+		pkg := code.getPackage(option)
+		if code.importPath == option.GetImportPath(pkg) {
+			continue
+		}
+		entryList, err := fs.ReadDir(code.sourceFS, ".")
+		if nil != err {
+			return pathList, err
+		}
+
+		dirPath := option.GetDirPath(pkg)
+		if _, err = mkDir(dirPath); nil != err {
+			return pathList, err
+		}
+		for _, entry := range entryList {
+			source, err := fs.ReadFile(code.sourceFS, entry.Name())
+			if nil != err {
+				return pathList, err
+			}
+			content, err := renderBaseCode(entry.Name(), source, path.Base(pkg), importPathMap)
+			if nil != err {
+				return pathList, fmt.Errorf("%s: %w", entry.Name(), err)
+			}
+			savePath := filepath.Join(dirPath, strings.TrimSuffix(entry.Name(), ".go")+".gen.go")
+			if err = os.WriteFile(savePath, content, 0644); nil != err {
+				return pathList, err
+			}
+			pathList = append(pathList, savePath)
+		}
+	}
+	return pathList, nil
+}
+
+// renderBaseCode 修改源码的包名，并将 GenDict 的包替换为目标项目的包（包名不同时保留原包名作为导入名）
+func renderBaseCode(fileName string, source []byte, packageName string, importPathMap map[string]string) ([]byte, error) {
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, fileName, source, parser.ParseComments)
+	if nil != err {
+		return nil, err
+	}
+	file.Name.Name = packageName
+	for _, importSpec := range file.Imports {
+		importPath, _ := strconv.Unquote(importSpec.Path.Value)
+		targetImportPath, ok := importPathMap[importPath]
+		if !ok {
+			continue
+		}
+		if nil == importSpec.Name && path.Base(importPath) != path.Base(targetImportPath) {
+			importSpec.Name = ast.NewIdent(path.Base(importPath))
+		}
+		importSpec.Path.Value = strconv.Quote(targetImportPath)
+	}
+	ast.SortImports(fileSet, file)
+
+	var buf bytes.Buffer
+	if err = format.Node(&buf, fileSet, file); nil != err {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
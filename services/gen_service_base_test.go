@@ -0,0 +1,44 @@
+package services
+
+import (
+	"goDict/dao"
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+func TestRenderBaseCode(t *testing.T) {
+	source, err := fs.ReadFile(dao.SourceFS, "base_dao.go")
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	// 包名与原包名不同时以原包名导入，源码中的引用不变
+	importPathMap := map[string]string{"goDict/models": "example.com/app/internal/entity", "goDict/dao": "example.com/app/internal/repo"}
+	content, err := renderBaseCode("base_dao.go", source, "repo", importPathMap)
+	if nil != err {
+		t.Fatal(err)
+	}
+	code := string(content)
+	if !strings.HasPrefix(code, "package repo\n") {
+		t.Errorf("package clause not rewritten:\n%s", code[:strings.Index(code, "\n")])
+	}
+	if !strings.Contains(code, `models "example.com/app/internal/entity"`) {
+		t.Error("models import not rewritten")
+	}
+	if strings.Contains(code, `"goDict/`) {
+		t.Error("GenDict import left in generated code")
+	}
+}
+
+func TestGenerateOptionValidatePackage(t *testing.T) {
+	option := NewGenerateOption("out", "example.com/app")
+	if err := option.Validate(); nil != err {
+		t.Fatal(err)
+	}
+	// 基础代码的文件名在模型、DAO 目录中重复，目录不能相同
+	option.DaoPackage = option.ModelPackage + "/"
+	if err := option.Validate(); nil == err {
+		t.Error("same model and dao package accepted")
+	}
+}
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"goDict/models"
+	"goDict/utils"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// 类型映射文件名，用户模板目录中的同名文件覆盖内置映射中的同名项
+	typeMappingFileName = "type_mapping.json"
+	// 未单独配置方言时使用的映射
+	defaultTypeMappingDialect = "default"
+	// 未配置的数据类型使用的映射
+	fallbackTypeMappingKey = "*"
+)
+
+const (
+	typeMappingLanguageTypeScript = "typescript"
+	typeMappingLanguageJava       = "java"
+)
+
+// TypeMapping 数据类型映射：语言 => 方言 => 数据类型（小写、不含长度） => 目标类型
+type TypeMapping map[string]map[string]map[string]string
+
+// loadTypeMapping 读取内置类型映射，并合并用户模板目录中的映射
+func loadTypeMapping(templateDirPath string) (TypeMapping, error) {
+	typeMapping := TypeMapping{}
+	content, err := templateFiles.ReadFile("templates/" + typeMappingFileName)
+	if nil != err {
+		return nil, err
+	}
+	if err = json.Unmarshal(content, &typeMapping); nil != err {
+		return nil, fmt.Errorf("%s: %w", typeMappingFileName, err)
+	}
+
+	userTypeMappingPath := filepath.Join(templateDirPath, typeMappingFileName)
+	if "" == templateDirPath || !utils.FileExists(userTypeMappingPath) {
+		return typeMapping, nil
+	}
+	content, err = os.ReadFile(userTypeMappingPath)
+	if nil != err {
+		return nil, err
+	}
+	userTypeMapping := TypeMapping{}
+	if err = json.Unmarshal(content, &userTypeMapping); nil != err {
+		return nil, fmt.Errorf("%s: %w", userTypeMappingPath, err)
+	}
+	for language, dialectMap := range userTypeMapping {
+		if nil == typeMapping[language] {
+			typeMapping[language] = map[string]map[string]string{}
+		}
+		for dialect, dataTypeMap := range dialectMap {
+			if nil == typeMapping[language][dialect] {
+				typeMapping[language][dialect] = map[string]string{}
+			}
+			for dataType, targetType := range dataTypeMap {
+				typeMapping[language][dialect][normalizeDataType(dataType)] = targetType
+			}
+		}
+	}
+	return typeMapping, nil
+}
+
+// Lookup 查找目标类型，依次查找方言、基础方言（如 mariadb 使用 mysql）、默认映射，均未配置时使用 * 项
+func (this TypeMapping) Lookup(language string, dialect string, dataType string) string {
+	dataType = normalizeDataType(dataType)
+	dialectList := []string{dialect}
+	if baseDialect, ok := compatibleDialectMap[dialect]; ok {
+		dialectList = append(dialectList, baseDialect)
+	}
+	dialectList = append(dialectList, defaultTypeMappingDialect)
+
+	for _, key := range []string{dataType, fallbackTypeMappingKey} {
+		for _, dialectName := range dialectList {
+			if targetType, ok := this[language][dialectName][key]; ok {
+				return targetType
+			}
+		}
+	}
+	return ""
+}
+
+// normalizeDataType 统一数据类型写法：小写，去掉长度、unsigned 及 ClickHouse 的 Nullable、LowCardinality 包装，
+// 如 "INT(11) UNSIGNED" 为 "int"，"Nullable(String)" 为 "string"
+func normalizeDataType(dataType string) string {
+	dataType = strings.ToLower(strings.TrimSpace(dataType))
+	for _, wrapper := range []string{"nullable(", "lowcardinality("} {
+		for strings.HasPrefix(dataType, wrapper) && strings.HasSuffix(dataType, ")") {
+			dataType = strings.TrimSpace(dataType[len(wrapper) : len(dataType)-1])
+		}
+	}
+	if index := strings.Index(dataType, "("); 0 <= index {
+		dataType = dataType[:index] + dataType[strings.LastIndex(dataType, ")")+1:]
+	}
+	for _, modifier := range []string{" unsigned", " zerofill"} {
+		dataType = strings.ReplaceAll(dataType, modifier, "")
+	}
+	return strings.Join(strings.Fields(dataType), " ")
+}
+
+// TypeScriptOptional 字段是否为可选属性：可为空且不是主键
+func (this *TemplateData) TypeScriptOptional(column *models.ColumnInfo) bool {
+	return column.Nullable && !column.IsPrimary
+}
+
+// TypeScriptType 字段对应的 TypeScript 类型
+func (this *TemplateData) TypeScriptType(column *models.ColumnInfo) string {
+	return this.TypeMapping.Lookup(typeMappingLanguageTypeScript, this.Dialect, column.DataType)
+}
+
+// javaQualifiedType 字段对应的 Java 类型全名
+func (this *TemplateData) javaQualifiedType(column *models.ColumnInfo) string {
+	return this.TypeMapping.Lookup(typeMappingLanguageJava, this.Dialect, column.DataType)
+}
+
+// JavaType 字段对应的 Java 类型，不含包名
+func (this *TemplateData) JavaType(column *models.ColumnInfo) string {
+	qualifiedType := this.javaQualifiedType(column)
+	return qualifiedType[strings.LastIndex(qualifiedType, ".")+1:]
+}
+
+// JavaImportList Java 实体需要导入的类型（java.lang 以外的类型）
+func (this *TemplateData) JavaImportList() []string {
+	importList := []string{}
+	if nil == this.Table {
+		return importList
+	}
+	for _, column := range this.Table.ColumnList {
+		qualifiedType := this.javaQualifiedType(column)
+		if strings.Contains(qualifiedType, ".") && !strings.HasPrefix(qualifiedType, "java.lang.") && !slices.Contains(importList, qualifiedType) {
+			importList = append(importList, qualifiedType)
+		}
+	}
+	slices.Sort(importList)
+	return importList
+}
+
+// JavaColumnAnnotation 字段的 @Column 注解，字符串字段包含长度，BigDecimal 字段包含精度
+func (this *TemplateData) JavaColumnAnnotation(column *models.ColumnInfo) string {
+	attributeList := []string{"name = " + strconv.Quote(column.ColumnName)}
+	if !column.Nullable || column.IsPrimary {
+		attributeList = append(attributeList, "nullable = false")
+	}
+	switch this.JavaType(column) {
+	case "String":
+		// 超出 int 范围的长度（如 longtext）不输出
+		if 0 < column.Length && math.MaxInt32 >= column.Length {
+			attributeList = append(attributeList, fmt.Sprintf("length = %d", column.Length))
+		}
+	case "BigDecimal":
+		if 0 < column.Precision {
+			attributeList = append(attributeList, fmt.Sprintf("precision = %d", column.Precision))
+		}
+		if 0 < column.Scale {
+			attributeList = append(attributeList, fmt.Sprintf("scale = %d", column.Scale))
+		}
+	}
+	// 生成列由数据库计算
+	if column.IsGenerated {
+		attributeList = append(attributeList, "insertable = false", "updatable = false")
+	}
+	return fmt.Sprintf("@Column(%s)", strings.Join(attributeList, ", "))
+}
+
+// Java 关键字，用作字段名时加下划线前缀
+var javaKeywordList = []string{
+	"abstract", "assert", "boolean", "break", "byte", "case", "catch", "char", "class", "const", "continue",
+	"default", "do", "double", "else", "enum", "extends", "final", "finally", "float", "for", "goto", "if",
+	"implements", "import", "instanceof", "int", "interface", "long", "native", "new", "package", "private",
+	"protected", "public", "return", "short", "static", "strictfp", "super", "switch", "synchronized", "this",
+	"throw", "throws", "transient", "try", "void", "volatile", "while", "true", "false", "null",
+}
+
+// JavaFieldName 字段对应的 Java 属性名（小驼峰）
+func (this *TemplateData) JavaFieldName(column *models.ColumnInfo) string {
+	fieldName := toCamelCase(column.ColumnName)
+	if "" == fieldName || unicode.IsDigit([]rune(fieldName)[0]) || slices.Contains(javaKeywordList, fieldName) {
+		fieldName = "_" + fieldName
+	}
+	return fieldName
+}
+
+// JavaAccessorName 字段 getter、setter 方法名中的属性名（大驼峰），如 getOrderNo 中的 OrderNo
+func (this *TemplateData) JavaAccessorName(column *models.ColumnInfo) string {
+	accessorName := toPascalCase(column.ColumnName)
+	// getClass 与 Object.getClass 冲突
+	if "Class" == accessorName {
+		accessorName = "Clazz"
+	}
+	return accessorName
+}
+
+// toCamelCase 下划线、空格等分隔的名称转为小驼峰，如 order_item、ORDER_ITEM 为 orderItem
+func toCamelCase(s string) string {
+	wordList := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var builder strings.Builder
+	for i, word := range wordList {
+		// 全大写的单词按普通单词处理
+		if strings.ToUpper(word) == word {
+			word = strings.ToLower(word)
+		}
+		runeList := []rune(word)
+		if 0 == i {
+			runeList[0] = unicode.ToLower(runeList[0])
+		} else {
+			runeList[0] = unicode.ToUpper(runeList[0])
+		}
+		builder.WriteString(string(runeList))
+	}
+	return builder.String()
+}
+
+// toPascalCase 转为大驼峰，如 order_item 为 OrderItem
+func toPascalCase(s string) string {
+	runeList := []rune(toCamelCase(s))
+	if 0 == len(runeList) {
+		return ""
+	}
+	runeList[0] = unicode.ToUpper(runeList[0])
+	return string(runeList)
+}
+
+// TypeScript 标识符
+var typeScriptIdentifierRegexp = regexp.MustCompile(`^[A-Za-z_$][\w$]*$`)
+
+// toTypeScriptPropertyName TypeScript 属性名，不是合法标识符时加引号
+func toTypeScriptPropertyName(s string) string {
+	if typeScriptIdentifierRegexp.MatchString(s) {
+		return s
+	}
+	return strconv.Quote(s)
+}
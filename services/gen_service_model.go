@@ -0,0 +1,281 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"goDict/models"
+	"golang.org/x/tools/go/ast/astutil"
+	"gorm.io/gorm/schema"
+	"log/slog"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// 模型基类名称
+const baseModelName = "BaseModel"
+
+// embeddedModel 可嵌入的基础模型，表中包含其全部列时嵌入
+type embeddedModel struct {
+	// 类型名称
+	name string
+	// 零值，用于解析字段
+	value interface{}
+}
+
+// embeddedModelList 可嵌入的基础模型，按顺序嵌入在结构体开头
+var embeddedModelList = []embeddedModel{
+	{name: baseModelName, value: &models.BaseModel{}},
+	{name: "SoftDeleteModel", value: &models.SoftDeleteModel{}},
+	{name: "AuditModel", value: &models.AuditModel{}},
+	{name: "VersionModel", value: &models.VersionModel{}},
+}
+
+// 整数类型，主键及基础模型字段比较时不区分位数和符号
+var integerTypeMap = map[string]bool{
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+}
+
+// baseModelField 基础模型的字段
+type baseModelField struct {
+	// 字段名
+	name string
+	// 类型，整数类型统一为 int
+	typeKind string
+}
+
+// getEmbeddedModelFieldMap 基础模型名称 => 列名 => 字段，由 gorm 解析 embeddedModelList 得到，基础模型增减字段时无需修改
+var getEmbeddedModelFieldMap = sync.OnceValue(func() map[string]map[string]baseModelField {
+	result := map[string]map[string]baseModelField{}
+	for _, model := range embeddedModelList {
+		modelSchema, err := schema.Parse(model.value, &sync.Map{}, schema.NamingStrategy{})
+		if nil != err {
+			slog.Error("解析基础模型失败", "model", model.name, "error", err)
+			continue
+		}
+		fieldMap := map[string]baseModelField{}
+		for _, field := range modelSchema.Fields {
+			if "" == field.DBName {
+				continue
+			}
+			typeKind := field.FieldType.String()
+			if integerTypeMap[field.FieldType.Kind().String()] {
+				typeKind = "int"
+			}
+			fieldMap[field.DBName] = baseModelField{name: field.Name, typeKind: typeKind}
+		}
+		result[model.name] = fieldMap
+	}
+	return result
+})
+
+// postProcessModel 处理 gorm/gen 生成的 model 文件：
+// 表中包含基础模型（BaseModel、SoftDeleteModel、AuditModel、VersionModel）的全部列时嵌入该模型并移除重复字段，
+// 未嵌入 BaseModel 时补充 GetID 方法；
+// TableName 改为值接收者，使模型实现 models.IEntity；最后 gofmt
+func (gs *GeneratorService) postProcessModel(templateData *TemplateData) (string, error) {
+	// Args
+	if nil == templateData {
+		return "", errors.New("无效的模板数据")
+	}
+
+	// model文件路径
+	modelPath := templateData.GetModelFilePath()
+	// 获取文件权限
+	info, err := os.Stat(modelPath)
+	if err != nil {
+		return "", err
+	}
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, modelPath, nil, parser.ParseComments)
+	if err != nil {
+		return "", err
+	}
+
+	// 追加的方法
+	var methodList []string
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok || templateData.UpperModelName != typeSpec.Name.Name {
+					continue
+				}
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+				embeddedBaseModel := false
+				for _, model := range embeddedModelList {
+					if embedModel(file, structType, model.name) && baseModelName == model.name {
+						embeddedBaseModel = true
+					}
+				}
+				if !embeddedBaseModel {
+					methodList = append(methodList, buildGetIDMethod(typeSpec.Name.Name, structType))
+				}
+			}
+		case *ast.FuncDecl:
+			// TableName 改为值接收者
+			if nil != decl.Recv && 1 == len(decl.Recv.List) && "TableName" == decl.Name.Name {
+				if starExpr, ok := decl.Recv.List[0].Type.(*ast.StarExpr); ok {
+					decl.Recv.List[0].Type = starExpr.X
+				}
+			}
+		}
+	}
+
+	// 移除重复字段后不再使用的导入，如 time
+	for _, importSpec := range file.Imports {
+		importPath, _ := strconv.Unquote(importSpec.Path.Value)
+		if !astutil.UsesImport(file, importPath) {
+			astutil.DeleteImport(fileSet, file, importPath)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err = format.Node(&buf, fileSet, file); err != nil {
+		return "", err
+	}
+	for _, method := range methodList {
+		buf.WriteString("\n" + method)
+	}
+	content, err := format.Source(buf.Bytes())
+	if err != nil {
+		return "", fmt.Errorf("格式化 model 失败: %w", err)
+	}
+
+	// 写入文件
+	if err = os.WriteFile(modelPath, content, info.Mode().Perm()); err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
+
+// embedModel 结构体包含基础模型的全部列（列名及类型一致）时嵌入该模型，并移除重复的字段及其注释；
+// 基础模型由 GenerateBaseCode 输出到模型目录，与生成的模型同包，直接以类型名嵌入
+func embedModel(file *ast.File, structType *ast.StructType, modelName string) bool {
+	modelFieldMap := getEmbeddedModelFieldMap()[modelName]
+	if 0 == len(modelFieldMap) {
+		return false
+	}
+	// 已嵌入；新嵌入的模型放在已嵌入的字段之后
+	embeddedCount := 0
+	for _, field := range structType.Fields.List {
+		if 0 != len(field.Names) {
+			break
+		}
+		if modelName == getTypeKind(field.Type) {
+			return true
+		}
+		embeddedCount++
+	}
+
+	// 查找与基础模型重复的字段
+	duplicatedFieldList := []*ast.Field{}
+	for columnName, modelField := range modelFieldMap {
+		index := slices.IndexFunc(structType.Fields.List, func(field *ast.Field) bool {
+			return columnName == getColumnName(field) && modelField.typeKind == getTypeKind(field.Type)
+		})
+		if 0 > index {
+			return false
+		}
+		duplicatedFieldList = append(duplicatedFieldList, structType.Fields.List[index])
+	}
+
+	// 嵌入基础模型，移除重复字段
+	removedCommentList := []*ast.CommentGroup{}
+	fieldList := slices.Clone(structType.Fields.List[:embeddedCount])
+	fieldList = append(fieldList, &ast.Field{Type: ast.NewIdent(modelName)})
+	for _, field := range structType.Fields.List[embeddedCount:] {
+		if slices.Contains(duplicatedFieldList, field) {
+			removedCommentList = append(removedCommentList, field.Doc, field.Comment)
+			continue
+		}
+		fieldList = append(fieldList, field)
+	}
+	structType.Fields.List = fieldList
+	// 注释保存在文件中，需一并移除，否则会错位输出
+	file.Comments = slices.DeleteFunc(file.Comments, func(commentGroup *ast.CommentGroup) bool {
+		return slices.Contains(removedCommentList, commentGroup)
+	})
+	return true
+}
+
+// buildGetIDMethod 未嵌入 BaseModel 时生成 GetID 方法，返回整数主键
+func buildGetIDMethod(modelName string, structType *ast.StructType) string {
+	for _, field := range structType.Fields.List {
+		if 1 != len(field.Names) || "int" != getTypeKind(field.Type) || !isPrimaryKey(field) {
+			continue
+		}
+		fieldName := field.Names[0].Name
+		if _, ok := field.Type.(*ast.StarExpr); ok {
+			return fmt.Sprintf("// GetID 主键\nfunc (m %s) GetID() uint {\n\tif nil == m.%s {\n\t\treturn 0\n\t}\n\treturn uint(*m.%s)\n}\n", modelName, fieldName, fieldName)
+		}
+		return fmt.Sprintf("// GetID 主键\nfunc (m %s) GetID() uint {\n\treturn uint(m.%s)\n}\n", modelName, fieldName)
+	}
+	return fmt.Sprintf("// GetID 没有整数主键，按主键操作的方法不可用\nfunc (m %s) GetID() uint {\n\treturn 0\n}\n", modelName)
+}
+
+// getTypeKind 字段类型，忽略指针，整数类型统一为 int
+func getTypeKind(expr ast.Expr) string {
+	if starExpr, ok := expr.(*ast.StarExpr); ok {
+		expr = starExpr.X
+	}
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		if integerTypeMap[expr.Name] {
+			return "int"
+		}
+		return expr.Name
+	case *ast.SelectorExpr:
+		if pkgIdent, ok := expr.X.(*ast.Ident); ok {
+			return pkgIdent.Name + "." + expr.Sel.Name
+		}
+	}
+	return ""
+}
+
+// getGormTagList 字段 gorm 标签的各项
+func getGormTagList(field *ast.Field) []string {
+	if nil == field.Tag {
+		return nil
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if nil != err {
+		return nil
+	}
+	return strings.Split(reflect.StructTag(tag).Get("gorm"), ";")
+}
+
+// getColumnName 字段对应的列名
+func getColumnName(field *ast.Field) string {
+	for _, item := range getGormTagList(field) {
+		if key, value, ok := strings.Cut(item, ":"); ok && strings.EqualFold("column", strings.TrimSpace(key)) {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// isPrimaryKey 是否主键
+func isPrimaryKey(field *ast.Field) bool {
+	for _, item := range getGormTagList(field) {
+		item = strings.TrimSpace(item)
+		if strings.EqualFold("primaryKey", item) || strings.EqualFold("primary_key", item) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,277 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"goDict/models"
+	"goDict/utils"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	typeMappingLanguageProto = "proto"
+	// 字段编号锁定文件名，位于 proto 目录
+	protoFieldNumberLockFileName = "field_number.lock.json"
+	// protobuf 保留的字段编号范围
+	protoMinReservedFieldNumber = 19000
+	protoMaxReservedFieldNumber = 19999
+	// 最大字段编号
+	protoMaxFieldNumber = 1<<29 - 1
+)
+
+// proto 包名，如 acme.entity
+var protoPackageNameRegexp = regexp.MustCompile(`^[A-Za-z_]\w*(\.[A-Za-z_]\w*)*$`)
+
+// protoWrapperTypeMap 标量类型 => 包装类型，可为空的字段使用包装类型
+var protoWrapperTypeMap = map[string]string{
+	"double": "google.protobuf.DoubleValue",
+	"float":  "google.protobuf.FloatValue",
+	"int64":  "google.protobuf.Int64Value",
+	"uint64": "google.protobuf.UInt64Value",
+	"int32":  "google.protobuf.Int32Value",
+	"uint32": "google.protobuf.UInt32Value",
+	"bool":   "google.protobuf.BoolValue",
+	"string": "google.protobuf.StringValue",
+	"bytes":  "google.protobuf.BytesValue",
+}
+
+// protoWellKnownTypeImportMap 常用类型 => 导入的文件，包装类型均在 wrappers.proto 中
+var protoWellKnownTypeImportMap = map[string]string{
+	"google.protobuf.Timestamp": "google/protobuf/timestamp.proto",
+	"google.protobuf.Duration":  "google/protobuf/duration.proto",
+	"google.protobuf.Struct":    "google/protobuf/struct.proto",
+	"google.protobuf.Any":       "google/protobuf/any.proto",
+}
+
+// ProtoField proto 消息的字段
+type ProtoField struct {
+	Name    string
+	Type    string
+	Number  int
+	Comment string
+}
+
+// ProtoFieldNumberLock 已分配的字段编号：表名 => 列名 => 编号。
+// 编号一经分配不再改变，新增列不会使已有字段重新编号，删除的列保留编号（生成 reserved）
+type ProtoFieldNumberLock map[string]map[string]int
+
+// loadProtoFieldNumberLock 读取字段编号锁定文件，文件不存在时为空
+func loadProtoFieldNumberLock(filePath string) (ProtoFieldNumberLock, error) {
+	lock := ProtoFieldNumberLock{}
+	if !utils.FileExists(filePath) {
+		return lock, nil
+	}
+	content, err := os.ReadFile(filePath)
+	if nil != err {
+		return nil, err
+	}
+	if err = json.Unmarshal(content, &lock); nil != err {
+		return nil, err
+	}
+	return lock, nil
+}
+
+// Save 保存字段编号锁定文件
+func (this ProtoFieldNumberLock) Save(filePath string) error {
+	content, err := json.MarshalIndent(this, "", "  ")
+	if nil != err {
+		return err
+	}
+	if _, err = mkDir(filepath.Dir(filePath)); nil != err {
+		return err
+	}
+	return os.WriteFile(filePath, append(content, '\n'), 0644)
+}
+
+// Assign 为表中尚未分配编号的列分配编号，返回该表全部已分配的编号（含已删除的列）。
+// 新列优先使用 ColumnInfo.Sort，已被占用或无效时使用最大编号之后的编号
+func (this ProtoFieldNumberLock) Assign(tableInfo *models.TableInfo) map[string]int {
+	fieldNumberMap := this[tableInfo.TableName]
+	if nil == fieldNumberMap {
+		fieldNumberMap = map[string]int{}
+		this[tableInfo.TableName] = fieldNumberMap
+	}
+	usedNumberMap := map[int]bool{}
+	maxNumber := 0
+	for _, number := range fieldNumberMap {
+		usedNumberMap[number] = true
+		maxNumber = max(maxNumber, number)
+	}
+
+	columnList := slices.Clone(tableInfo.ColumnList)
+	slices.SortStableFunc(columnList, func(a *models.ColumnInfo, b *models.ColumnInfo) int {
+		return a.Sort - b.Sort
+	})
+	for _, column := range columnList {
+		if _, ok := fieldNumberMap[column.ColumnName]; ok {
+			continue
+		}
+		number := column.Sort
+		if !isValidProtoFieldNumber(number) || usedNumberMap[number] {
+			number = maxNumber + 1
+			if !isValidProtoFieldNumber(number) {
+				number = protoMaxReservedFieldNumber + 1
+			}
+		}
+		fieldNumberMap[column.ColumnName] = number
+		usedNumberMap[number] = true
+		maxNumber = max(maxNumber, number)
+	}
+	return fieldNumberMap
+}
+
+// isValidProtoFieldNumber 是否可用的字段编号
+func isValidProtoFieldNumber(number int) bool {
+	return 0 < number && protoMaxFieldNumber >= number && (protoMinReservedFieldNumber > number || protoMaxReservedFieldNumber < number)
+}
+
+// ProtoGoPackage proto 文件的 go_package
+func (this *TemplateData) ProtoGoPackage() string {
+	return this.Option.GetImportPath(this.Option.ProtoPackage)
+}
+
+// protoType 字段对应的 proto 类型，可为空且不是主键的标量字段使用包装类型
+func (this *TemplateData) protoType(column *models.ColumnInfo) string {
+	protoType := this.TypeMapping.Lookup(typeMappingLanguageProto, this.Dialect, column.DataType)
+	if wrapperType, ok := protoWrapperTypeMap[protoType]; ok && column.Nullable && !column.IsPrimary {
+		return wrapperType
+	}
+	return protoType
+}
+
+// ProtoFieldList 消息的字段，按列的顺序
+func (this *TemplateData) ProtoFieldList() ([]*ProtoField, error) {
+	fieldList := []*ProtoField{}
+	if nil == this.Table {
+		return fieldList, nil
+	}
+	fieldNameMap, err := this.protoFieldNameMap()
+	if nil != err {
+		return nil, err
+	}
+	for _, column := range this.Table.ColumnList {
+		number, ok := this.ProtoFieldNumberMap[column.ColumnName]
+		if !ok {
+			continue
+		}
+		fieldList = append(fieldList, &ProtoField{
+			Name:    fieldNameMap[column.ColumnName],
+			Type:    this.protoType(column),
+			Number:  number,
+			Comment: column.Comment,
+		})
+	}
+	return fieldList, nil
+}
+
+// ProtoReservedNumberList 已删除的列的编号
+func (this *TemplateData) ProtoReservedNumberList() []int {
+	numberList := []int{}
+	for _, columnName := range this.protoRemovedColumnNameList() {
+		numberList = append(numberList, this.ProtoFieldNumberMap[columnName])
+	}
+	slices.Sort(numberList)
+	return numberList
+}
+
+// ProtoReservedNameList 已删除的列的字段名
+func (this *TemplateData) ProtoReservedNameList() ([]string, error) {
+	if nil == this.Table {
+		return []string{}, nil
+	}
+	fieldNameMap, err := this.protoFieldNameMap()
+	if nil != err {
+		return nil, err
+	}
+	nameList := []string{}
+	for _, columnName := range this.protoRemovedColumnNameList() {
+		nameList = append(nameList, fieldNameMap[columnName])
+	}
+	slices.Sort(nameList)
+	return nameList, nil
+}
+
+// protoFieldNameMap 已分配编号的列（含已删除的列）的字段名：列名 => 字段名。
+// 转换后重名的列（如 A-b 与 a_b）加字段编号后缀（编号已锁定，字段名不随列的增减变化），列名本身即为该字段名的列保留原名；
+// 加后缀后仍重名时返回错误
+func (this *TemplateData) protoFieldNameMap() (map[string]string, error) {
+	// 字段名 => 列名
+	columnNameListMap := map[string][]string{}
+	for columnName := range this.ProtoFieldNumberMap {
+		fieldName := toProtoFieldName(columnName)
+		columnNameListMap[fieldName] = append(columnNameListMap[fieldName], columnName)
+	}
+
+	fieldNameMap := map[string]string{}
+	for fieldName, columnNameList := range columnNameListMap {
+		for _, columnName := range columnNameList {
+			if 1 < len(columnNameList) && fieldName != columnName {
+				fieldNameMap[columnName] = fmt.Sprintf("%s_%d", fieldName, this.ProtoFieldNumberMap[columnName])
+			} else {
+				fieldNameMap[columnName] = fieldName
+			}
+		}
+	}
+
+	// 字段名 => 列名，检查加后缀后是否重名
+	fieldColumnNameMap := map[string]string{}
+	for columnName, fieldName := range fieldNameMap {
+		if otherColumnName, ok := fieldColumnNameMap[fieldName]; ok {
+			columnNameList := []string{columnName, otherColumnName}
+			slices.Sort(columnNameList)
+			return nil, fmt.Errorf("表 %s 的列 %s 与 %s 的 proto 字段名重复: %s", this.Table.TableName, columnNameList[0], columnNameList[1], fieldName)
+		}
+		fieldColumnNameMap[fieldName] = columnName
+	}
+	return fieldNameMap, nil
+}
+
+// protoRemovedColumnNameList 锁定文件中有、表中已不存在的列
+func (this *TemplateData) protoRemovedColumnNameList() []string {
+	columnNameList := []string{}
+	if nil == this.Table {
+		return columnNameList
+	}
+	for columnName := range this.ProtoFieldNumberMap {
+		if !slices.ContainsFunc(this.Table.ColumnList, func(column *models.ColumnInfo) bool { return columnName == column.ColumnName }) {
+			columnNameList = append(columnNameList, columnName)
+		}
+	}
+	return columnNameList
+}
+
+// ProtoImportList 需要导入的 proto 文件
+func (this *TemplateData) ProtoImportList() ([]string, error) {
+	fieldList, err := this.ProtoFieldList()
+	if nil != err {
+		return nil, err
+	}
+	importList := []string{}
+	for _, field := range fieldList {
+		importPath := protoWellKnownTypeImportMap[field.Type]
+		if strings.HasPrefix(field.Type, "google.protobuf.") && strings.HasSuffix(field.Type, "Value") {
+			importPath = "google/protobuf/wrappers.proto"
+		}
+		if "" != importPath && !slices.Contains(importList, importPath) {
+			importList = append(importList, importPath)
+		}
+	}
+	slices.Sort(importList)
+	return importList, nil
+}
+
+// Proto 字段名中不允许的字符
+var protoInvalidFieldNameCharRegexp = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// toProtoFieldName 列名转为 proto 字段名（小写，其他字符替换为下划线），不以字母开头时加 f_ 前缀
+func toProtoFieldName(columnName string) string {
+	fieldName := protoInvalidFieldNameCharRegexp.ReplaceAllString(strings.ToLower(columnName), "_")
+	if "" == fieldName || 'a' > fieldName[0] || 'z' < fieldName[0] {
+		fieldName = "f_" + fieldName
+	}
+	return fieldName
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"goDict/models"
+	"slices"
+	"testing"
+)
+
+func TestProtoFieldNameCollision(t *testing.T) {
+	typeMapping, err := loadTypeMapping("")
+	if nil != err {
+		t.Fatal(err)
+	}
+	table := &models.TableInfo{TableName: "item", ColumnList: []*models.ColumnInfo{
+		{Sort: 1, ColumnName: "A-b", DataType: "int"},
+		{Sort: 2, ColumnName: "a_b", DataType: "int"},
+		{Sort: 3, ColumnName: "Name", DataType: "text"},
+	}}
+	// 已删除的列 A_B 与现有列转换后同名
+	templateData := &TemplateData{Table: table, Dialect: "sqlite", TypeMapping: typeMapping,
+		ProtoFieldNumberMap: map[string]int{"A-b": 1, "a_b": 2, "Name": 3, "A_B": 4}}
+
+	// 列名本身即字段名的保留原名，其余加编号后缀
+	fieldList, err := templateData.ProtoFieldList()
+	if nil != err {
+		t.Fatal(err)
+	}
+	nameList := []string{}
+	for _, field := range fieldList {
+		nameList = append(nameList, field.Name)
+	}
+	if want := []string{"a_b_1", "a_b", "name"}; !slices.Equal(want, nameList) {
+		t.Errorf("field names = %v, want %v", nameList, want)
+	}
+	reservedNameList, err := templateData.ProtoReservedNameList()
+	if nil != err {
+		t.Fatal(err)
+	}
+	if want := []string{"a_b_4"}; !slices.Equal(want, reservedNameList) {
+		t.Errorf("reserved names = %v, want %v", reservedNameList, want)
+	}
+
+	// 加后缀后仍重名
+	table.ColumnList = append(table.ColumnList, &models.ColumnInfo{Sort: 5, ColumnName: "a_b_1", DataType: "int"})
+	templateData.ProtoFieldNumberMap["a_b_1"] = 5
+	if _, err = templateData.ProtoFieldList(); nil == err {
+		t.Error("duplicate field name a_b_1 accepted")
+	}
+}
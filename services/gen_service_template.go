@@ -0,0 +1,211 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"goDict/models"
+	"goDict/utils"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// codeTemplate 按模型生成的代码模板
+type codeTemplate struct {
+	// 模板文件名，用户模板目录中的同名文件优先
+	name string
+	// 输出文件名
+	getFileName func(templateData *TemplateData) string
+	// 输出目录，为空时不生成
+	getPackage func(option *GenerateOption) string
+}
+
+// codeTemplateList 每个模型依次生成的代码
+var codeTemplateList = []codeTemplate{
+	{"gen_dao.go.tmpl", snakeFileName("%s_dao.gen.go"), func(option *GenerateOption) string { return option.DaoPackage }},
+	{"gen_service.go.tmpl", snakeFileName("%s_service.gen.go"), func(option *GenerateOption) string { return option.ServicePackage }},
+	{"gen_service_test.go.tmpl", snakeFileName("%s_service.gen_test.go"), func(option *GenerateOption) string { return option.ServicePackage }},
+	{"gen_handler.go.tmpl", snakeFileName("%s_handler.gen.go"), func(option *GenerateOption) string { return option.HandlerPackage }},
+	{"gen_typescript.ts.tmpl", snakeFileName("%s.ts"), func(option *GenerateOption) string { return option.TypeScriptPackage }},
+	{"gen_java.java.tmpl", func(templateData *TemplateData) string { return templateData.UpperModelName + ".java" }, (*GenerateOption).GetJavaSourcePackage},
+	{"gen_proto.proto.tmpl", snakeFileName("%s.proto"), func(option *GenerateOption) string { return option.ProtoPackage }},
+}
+
+// commonCodeTemplateList 每个包只生成一次的公共代码，如 HTTP 处理器共用的错误响应、分页参数解析
+var commonCodeTemplateList = []codeTemplate{
+	{"gen_handler_common.go.tmpl", func(*TemplateData) string { return "handler.gen.go" }, func(option *GenerateOption) string { return option.HandlerPackage }},
+}
+
+// snakeFileName 以模型蛇形名称命名的输出文件，format 中 %s 为模型的蛇形名称
+func snakeFileName(format string) func(templateData *TemplateData) string {
+	return func(templateData *TemplateData) string {
+		return fmt.Sprintf(format, templateData.SnakeModelName)
+	}
+}
+
+// codeTemplateFuncMap 模板中可用的函数
+var codeTemplateFuncMap = template.FuncMap{
+	"lower":  strings.ToLower,
+	"upper":  strings.ToUpper,
+	"snake":  func(s string) string { return strings.ToLower(utils.ToSnakeCase(s)) },
+	"camel":  toCamelCase,
+	"pascal": toPascalCase,
+	// TypeScript 属性名，不是合法标识符时加引号
+	"tsKey": toTypeScriptPropertyName,
+	// 按行拆分，用于输出多行的行注释
+	"lines": func(s string) []string {
+		return strings.FieldsFunc(s, func(r rune) bool { return '\n' == r || '\r' == r })
+	},
+	// 块注释内容，转义注释结束符并合并换行
+	"comment": func(s string) string {
+		return strings.Join(strings.Fields(strings.ReplaceAll(s, "*/", "*\\/")), " ")
+	},
+}
+
+// DefaultTemplateDirPath 默认的用户模板目录（用户配置目录下的 GenDict/templates）
+func DefaultTemplateDirPath() string {
+	configDir, err := os.UserConfigDir()
+	if nil != err {
+		return ""
+	}
+	return filepath.Join(configDir, "GenDict", "templates")
+}
+
+// ModelPackageName 模型包名
+func (this *TemplateData) ModelPackageName() string {
+	return path.Base(this.Option.ModelPackage)
+}
+
+// ModelImportPath 模型包导入路径
+func (this *TemplateData) ModelImportPath() string {
+	return this.Option.GetImportPath(this.Option.ModelPackage)
+}
+
+// DaoPackageName DAO 包名
+func (this *TemplateData) DaoPackageName() string {
+	return path.Base(this.Option.DaoPackage)
+}
+
+// DaoImportPath DAO 包导入路径
+func (this *TemplateData) DaoImportPath() string {
+	return this.Option.GetImportPath(this.Option.DaoPackage)
+}
+
+// ServicePackageName 服务包名
+func (this *TemplateData) ServicePackageName() string {
+	return path.Base(this.Option.ServicePackage)
+}
+
+// ServiceImportPath 服务包导入路径
+func (this *TemplateData) ServiceImportPath() string {
+	return this.Option.GetImportPath(this.Option.ServicePackage)
+}
+
+// HandlerPackageName HTTP 处理器包名
+func (this *TemplateData) HandlerPackageName() string {
+	return path.Base(this.Option.HandlerPackage)
+}
+
+// HandlerImportPath HTTP 处理器包导入路径
+func (this *TemplateData) HandlerImportPath() string {
+	return this.Option.GetImportPath(this.Option.HandlerPackage)
+}
+
+// GenerateCode 根据模板生成模型对应的代码，返回生成的文件列表
+func (gs *GeneratorService) GenerateCode(templateData *TemplateData) ([]string, error) {
+	pathList := []string{}
+	for _, codeTemplate := range codeTemplateList {
+		savePath, err := gs.renderCodeTemplate(codeTemplate, templateData)
+		if nil != err {
+			return pathList, fmt.Errorf("%s: %w", codeTemplate.name, err)
+		}
+		if "" != savePath {
+			pathList = append(pathList, savePath)
+		}
+	}
+	return pathList, nil
+}
+
+// GenerateCommonCode 根据模板生成各模型共用的代码，返回生成的文件列表
+func (gs *GeneratorService) GenerateCommonCode(option *GenerateOption) ([]string, error) {
+	templateData := &TemplateData{Option: option}
+	pathList := []string{}
+	for _, codeTemplate := range commonCodeTemplateList {
+		savePath, err := gs.renderCodeTemplate(codeTemplate, templateData)
+		if nil != err {
+			return pathList, fmt.Errorf("%s: %w", codeTemplate.name, err)
+		}
+		if "" != savePath {
+			pathList = append(pathList, savePath)
+		}
+	}
+	return pathList, nil
+}
+
+// renderCodeTemplate 渲染单个模板，Go 代码格式化，输出目录或渲染结果为空时不生成文件
+func (gs *GeneratorService) renderCodeTemplate(codeTemplate codeTemplate, templateData *TemplateData) (string, error) {
+	pkg := codeTemplate.getPackage(templateData.Option)
+	if "" == pkg {
+		return "", nil
+	}
+	t, err := gs.parseCodeTemplate(templateData.Option, codeTemplate.name)
+	if nil != err {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err = t.Execute(&buf, templateData); nil != err {
+		return "", err
+	}
+	// 用户模板可以渲染为空以跳过该文件
+	if "" == strings.TrimSpace(buf.String()) {
+		return "", nil
+	}
+	fileName := codeTemplate.getFileName(templateData)
+	content := buf.Bytes()
+	if ".go" == filepath.Ext(fileName) {
+		if content, err = format.Source(content); nil != err {
+			return "", fmt.Errorf("格式化失败: %w", err)
+		}
+	}
+
+	dirPath := templateData.Option.GetDirPath(pkg)
+	if _, err = mkDir(dirPath); nil != err {
+		return "", err
+	}
+	savePath := filepath.Join(dirPath, fileName)
+	if err = os.WriteFile(savePath, content, 0644); nil != err {
+		return "", err
+	}
+	return savePath, nil
+}
+
+// parseCodeTemplate 读取模板，用户模板目录中存在同名文件时优先使用，否则使用内置模板
+func (gs *GeneratorService) parseCodeTemplate(option *GenerateOption, name string) (*template.Template, error) {
+	var content []byte
+	var err error
+	if userTemplatePath := filepath.Join(option.TemplateDirPath, name); "" != option.TemplateDirPath && utils.FileExists(userTemplatePath) {
+		content, err = os.ReadFile(userTemplatePath)
+	} else {
+		content, err = templateFiles.ReadFile(path.Join("templates", name))
+	}
+	if nil != err {
+		return nil, err
+	}
+
+	return template.New(name).Funcs(codeTemplateFuncMap).Parse(string(content))
+}
+
+// findTableInfo 查找表信息，未找到时返回 nil
+func findTableInfo(databaseInfo *models.DatabaseInfo, tableName string) *models.TableInfo {
+	if nil == databaseInfo {
+		return nil
+	}
+	if tableInfo, ok := databaseInfo.TableMap[tableName]; ok {
+		return &tableInfo
+	}
+	return nil
+}
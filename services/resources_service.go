@@ -6,3 +6,8 @@ import (
 
 //go:embed templates/*
 var templateFiles embed.FS
+
+// 生成代码时输出到目标项目服务目录的 BaseService
+//
+//go:embed base_service.go
+var baseServiceSourceFS embed.FS
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"golang.org/x/crypto/argon2"
+)
+
+// Argon2id 默认参数（OWASP 推荐的最低配置）
+const (
+	Argon2Time    uint32 = 3
+	Argon2Memory  uint32 = 64 * 1024
+	Argon2Threads uint8  = 2
+	// AES-256 密钥长度
+	aesKeyLength = 32
+)
+
+// RandomBytes 生成指定长度的随机字节
+func RandomBytes(length int) ([]byte, error) {
+	result := make([]byte, length)
+	if _, err := rand.Read(result); nil != err {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeriveKey 使用 Argon2id 从口令派生 AES-256 密钥
+func DeriveKey(secret []byte, salt []byte, time uint32, memory uint32, threads uint8) []byte {
+	return argon2.IDKey(secret, salt, time, memory, threads, aesKeyLength)
+}
+
+// EncryptAESGCM 使用 AES-GCM 加密，返回 nonce+密文
+func EncryptAESGCM(key []byte, plaintext []byte, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if nil != err {
+		return nil, err
+	}
+
+	nonce, err := RandomBytes(gcm.NonceSize())
+	if nil != err {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// DecryptAESGCM 解密 EncryptAESGCM 的结果，密钥错误或数据被篡改时返回错误
+func DecryptAESGCM(key []byte, data []byte, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if nil != err {
+		return nil, err
+	}
+
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("密文长度错误")
+	}
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, additionalData)
+}
+
+// newGCM 创建 AES-GCM
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if nil != err {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// 需要换行的SQL关键字
+var sqlClauseRegexp = regexp.MustCompile(`(?i)\s+(select|from|where|(?:left|right|inner|full|cross)(?:\s+outer)?\s+join|join|group\s+by|order\s+by|having|union(?:\s+all)?|limit)\b`)
+
+// FormatSql 简单格式化SQL：在主要子句前换行（已包含换行的SQL原样返回），
+// 字符串字面量、带引号的标识符及注释中的内容不处理
+func FormatSql(sql string) string {
+	sql = strings.TrimSpace(strings.ReplaceAll(sql, "\r", ""))
+	// 已经格式化过（PostgresSQL、SQLServer 等会保留原始换行）
+	if strings.Contains(sql, "\n") {
+		return sql
+	}
+
+	var builder strings.Builder
+	// 尚未处理的片段的起始位置
+	start := 0
+	for idx := 0; idx < len(sql); idx++ {
+		// 不处理的部分的结束位置（不含）
+		end := -1
+		switch {
+		case '\'' == sql[idx] || '"' == sql[idx] || '`' == sql[idx]:
+			// 双写的引号视为相邻的两段，结果相同
+			end = strings.IndexByte(sql[idx+1:], sql[idx])
+			if 0 <= end {
+				end += idx + 2
+			}
+		case strings.HasPrefix(sql[idx:], "/*"):
+			end = strings.Index(sql[idx+2:], "*/")
+			if 0 <= end {
+				end += idx + 4
+			}
+		case strings.HasPrefix(sql[idx:], "--"):
+			// 单行注释（SQL 中没有换行）直到末尾
+			end = len(sql)
+		default:
+			continue
+		}
+		// 未闭合时直到末尾
+		if 0 > end {
+			end = len(sql)
+		}
+
+		builder.WriteString(sqlClauseRegexp.ReplaceAllString(sql[start:idx], "\n$1"))
+		builder.WriteString(sql[idx:end])
+		start, idx = end, end-1
+	}
+	builder.WriteString(sqlClauseRegexp.ReplaceAllString(sql[start:], "\n$1"))
+
+	return builder.String()
+}
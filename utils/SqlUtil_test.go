@@ -0,0 +1,34 @@
+package utils
+
+import "testing"
+
+func TestFormatSql(t *testing.T) {
+	testCaseList := []struct {
+		name string
+		sql  string
+		want string
+	}{
+		{"clauses", "select a, b from t where x = 1 group by a having count(*) > 1 order by a limit 10",
+			"select a, b\nfrom t\nwhere x = 1\ngroup by a\nhaving count(*) > 1\norder by a\nlimit 10"},
+		{"joins", "SELECT * FROM a LEFT OUTER JOIN b ON a.id = b.id INNER JOIN c ON c.id = b.id JOIN d USING (id)",
+			"SELECT *\nFROM a\nLEFT OUTER JOIN b ON a.id = b.id\nINNER JOIN c ON c.id = b.id\nJOIN d USING (id)"},
+		{"subquery and union", "select id from (select id from t) s union all select id from u",
+			"select id\nfrom (select id\nfrom t) s\nunion all\nselect id\nfrom u"},
+		{"string literal", "select 'a from b where c' as x from t", "select 'a from b where c' as x\nfrom t"},
+		{"escaped quote", "select 'it''s from here' from t", "select 'it''s from here'\nfrom t"},
+		{"quoted identifier", `select "order by" from t`, "select \"order by\"\nfrom t"},
+		{"back-quoted identifier", "select `a from` from `where`", "select `a from`\nfrom `where`"},
+		{"block comment", "select a /* from b where c */ from t", "select a /* from b where c */\nfrom t"},
+		{"line comment", "select a from t -- where x order by y", "select a\nfrom t -- where x order by y"},
+		{"unclosed literal", "select a from t where x = 'from", "select a\nfrom t\nwhere x = 'from"},
+		{"word boundary", "select fromage, joined_at from t", "select fromage, joined_at\nfrom t"},
+		// 已包含换行的原样返回
+		{"formatted", "  select a\r\n  from t  ", "select a\n  from t"},
+		{"empty", "  ", ""},
+	}
+	for _, testCase := range testCaseList {
+		if result := FormatSql(testCase.sql); testCase.want != result {
+			t.Errorf("%s: FormatSql(%q) = %q, want %q", testCase.name, testCase.sql, result, testCase.want)
+		}
+	}
+}